@@ -0,0 +1,176 @@
+// Package audit records the write requests the CLI sends to the API in a
+// local JSON-lines file, and reads them back.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yannick/infomaniak/internal/state"
+)
+
+// FileName is the name of the audit log in the state directory.
+const FileName = "audit.log"
+
+// Entry is one line of the audit log.
+type Entry struct {
+	Time    time.Time       `json:"time"`
+	User    string          `json:"user,omitempty"`
+	Profile string          `json:"profile,omitempty"`
+	Command string          `json:"command,omitempty"`
+	Method  string          `json:"method"`
+	Path    string          `json:"path"`
+	Body    json.RawMessage `json:"body,omitempty"`
+	Status  int             `json:"status,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// Concerns reports whether the entry is about domain: the domain is a
+// segment of the request path or the "name" or "domain" field of its body.
+func (e *Entry) Concerns(domain string) bool {
+	domain = normalize(domain)
+
+	path, _, _ := strings.Cut(e.Path, "?")
+	for _, seg := range strings.Split(path, "/") {
+		if normalize(seg) == domain {
+			return true
+		}
+	}
+
+	var body struct {
+		Name   string `json:"name"`
+		Domain string `json:"domain"`
+	}
+	if json.Unmarshal(e.Body, &body) == nil {
+		return normalize(body.Name) == domain || normalize(body.Domain) == domain
+	}
+	return false
+}
+
+// DefaultPath returns the audit log location in the state directory.
+func DefaultPath() (string, error) {
+	dir, err := state.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// Log appends entries to an audit log file. It is safe for concurrent use.
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+// New returns a log that appends to the file at path.
+func New(path string) *Log {
+	return &Log{path: path}
+}
+
+// Path returns the location of the log file.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append writes e as a single line. The file is opened in append mode for
+// each entry so that several processes can share it.
+func (l *Log) Append(e Entry) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(e); err != nil {
+		return fmt.Errorf("encode audit entry: %w", err)
+	}
+	line := buf.Bytes()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return fmt.Errorf("create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	return nil
+}
+
+// Filter selects entries when reading the log. Zero fields match
+// everything.
+type Filter struct {
+	Since  time.Time
+	Domain string
+}
+
+func (f Filter) match(e *Entry) bool {
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	return f.Domain == "" || e.Concerns(f.Domain)
+}
+
+// Read returns the entries of the log at path that match filter, oldest
+// first. A missing file has no entries.
+func Read(path string, filter Filter) ([]Entry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := Parse(f, filter)
+	if err != nil {
+		return nil, fmt.Errorf("read audit log %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// Parse reads JSON-lines entries from r and returns those matching filter.
+func Parse(r io.Reader, filter Filter) ([]Entry, error) {
+	var entries []Entry
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if filter.match(&e) {
+			entries = append(entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func normalize(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
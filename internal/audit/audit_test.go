@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state", FileName)
+	log := New(path)
+
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{Time: now.Add(-10 * 24 * time.Hour), Method: "PUT", Path: "/2/domains/domains/old.ch/nameservers", Status: 204},
+		{Time: now.Add(-time.Hour), User: "alice", Profile: "company", Command: "infomaniak domains update-ns example.ch",
+			Method: "PUT", Path: "/2/domains/domains/example.ch/nameservers", Body: json.RawMessage(`{"nameservers":["ns1.example.net"]}`), Status: 204},
+		{Time: now, Method: "POST", Path: "/2/domains/transfers?account_id=1", Body: json.RawMessage(`{"name":"Example.CH","auth_code":"<redacted>"}`),
+			Status: 422, Error: "api error invalid_auth_code (status 422): wrong"},
+		{Time: now, Method: "POST", Path: "/2/domains/domains/example.com/dnssec", Status: 204},
+	}
+	for _, e := range entries {
+		if err := log.Append(e); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"all", Filter{}, []string{"old.ch", "example.ch", "transfers", "example.com"}},
+		{"since", Filter{Since: now.Add(-7 * 24 * time.Hour)}, []string{"example.ch", "transfers", "example.com"}},
+		{"domain in path or body", Filter{Domain: "example.ch"}, []string{"example.ch", "transfers"}},
+		{"domain and since", Filter{Domain: "old.ch", Since: now.Add(-7 * 24 * time.Hour)}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Read(path, tt.filter)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d entries, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(got[i].Path, want) {
+					t.Errorf("entry %d path = %s, want it to contain %s", i, got[i].Path, want)
+				}
+			}
+		})
+	}
+
+	got, _ := Read(path, Filter{Domain: "example.ch"})
+	if got[0].User != "alice" || got[0].Command == "" || string(got[0].Body) != `{"nameservers":["ns1.example.net"]}` {
+		t.Errorf("entry did not round-trip: %+v", got[0])
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 && os.PathSeparator == '/' {
+		t.Errorf("audit log permissions = %o, want owner only", perm)
+	}
+}
+
+func TestReadMissing(t *testing.T) {
+	t.Parallel()
+
+	entries, err := Read(filepath.Join(t.TempDir(), FileName), Filter{})
+	if err != nil || entries != nil {
+		t.Errorf("Read of missing file = %v, %v", entries, err)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse(strings.NewReader("{\"method\":\"PUT\"}\nnot json\n"), Filter{})
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("err = %v, want line 2 reported", err)
+	}
+}
+
+func TestConcurrentAppend(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), FileName)
+	log := New(path)
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := log.Append(Entry{Time: time.Now(), Method: "PUT", Path: fmt.Sprintf("/2/domains/domains/d%d.ch/nameservers", i)}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	entries, err := Read(path, Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 50 {
+		t.Errorf("got %d entries, want 50", len(entries))
+	}
+}
@@ -0,0 +1,114 @@
+// Package bulk runs an operation over many items with a bounded number of
+// workers and collects one result per item.
+package bulk
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Options controls how Run schedules work.
+type Options struct {
+	// Parallel is the maximum number of items processed at once. Values
+	// below 1 run the items one at a time.
+	Parallel int
+	// ContinueOnError keeps processing after a failure. Otherwise items not
+	// yet started when an item fails are skipped.
+	ContinueOnError bool
+}
+
+// Result is the outcome for one item.
+type Result[T any] struct {
+	Item    string
+	Value   T
+	Err     error
+	Skipped bool
+}
+
+// Run calls fn for every item and returns the results in item order.
+// Items that are not started because of an earlier failure or a cancelled
+// context are marked Skipped.
+func Run[T any](ctx context.Context, items []string, opts Options, fn func(context.Context, string) (T, error)) []Result[T] {
+	results := make([]Result[T], len(items))
+	for i, item := range items {
+		results[i].Item = item
+	}
+
+	workers := min(max(opts.Parallel, 1), len(items))
+
+	var stopped atomic.Bool
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if stopped.Load() || ctx.Err() != nil {
+					results[i].Skipped = true
+					continue
+				}
+				results[i].Value, results[i].Err = fn(ctx, items[i])
+				if results[i].Err != nil && !opts.ContinueOnError {
+					stopped.Store(true)
+				}
+			}
+		}()
+	}
+
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// Counts returns the number of succeeded, failed and skipped results.
+func Counts[T any](results []Result[T]) (ok, failed, skipped int) {
+	for _, r := range results {
+		switch {
+		case r.Skipped:
+			skipped++
+		case r.Err != nil:
+			failed++
+		default:
+			ok++
+		}
+	}
+	return ok, failed, skipped
+}
+
+// ReadList reads one item per line. Blank lines and lines starting with #
+// are ignored, only the first field of a line is used so that tabular
+// output can be piped in, and duplicates are dropped.
+func ReadList(r io.Reader) ([]string, error) {
+	var items []string
+	seen := map[string]bool{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		item := strings.Fields(line)[0]
+		if seen[item] {
+			continue
+		}
+		seen[item] = true
+		items = append(items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read list: %w", err)
+	}
+
+	return items, nil
+}
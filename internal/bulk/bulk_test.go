@@ -0,0 +1,120 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	items := []string{"a", "b", "fail", "c", "d"}
+
+	tests := []struct {
+		name        string
+		opts        Options
+		wantOK      int
+		wantFailed  int
+		wantSkipped int
+	}{
+		{name: "stop on error", opts: Options{Parallel: 1}, wantOK: 2, wantFailed: 1, wantSkipped: 2},
+		{name: "continue on error", opts: Options{Parallel: 1, ContinueOnError: true}, wantOK: 4, wantFailed: 1},
+		{name: "parallel continue", opts: Options{Parallel: 3, ContinueOnError: true}, wantOK: 4, wantFailed: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			results := Run(context.Background(), items, tt.opts, func(_ context.Context, item string) (string, error) {
+				if item == "fail" {
+					return "", errors.New("boom")
+				}
+				return strings.ToUpper(item), nil
+			})
+
+			if len(results) != len(items) {
+				t.Fatalf("got %d results, want %d", len(results), len(items))
+			}
+			for i, r := range results {
+				if r.Item != items[i] {
+					t.Errorf("results[%d].Item = %q, want %q", i, r.Item, items[i])
+				}
+				if r.Err == nil && !r.Skipped && r.Value != strings.ToUpper(r.Item) {
+					t.Errorf("results[%d].Value = %q", i, r.Value)
+				}
+			}
+
+			ok, failed, skipped := Counts(results)
+			if ok != tt.wantOK || failed != tt.wantFailed || skipped != tt.wantSkipped {
+				t.Errorf("Counts() = %d, %d, %d, want %d, %d, %d", ok, failed, skipped, tt.wantOK, tt.wantFailed, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestRunBoundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var running, peak atomic.Int32
+	items := make([]string, 20)
+	for i := range items {
+		items[i] = string(rune('a' + i))
+	}
+
+	Run(context.Background(), items, Options{Parallel: 4}, func(context.Context, string) (struct{}, error) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return struct{}{}, nil
+	})
+
+	if got := peak.Load(); got > 4 {
+		t.Errorf("peak concurrency = %d, want at most 4", got)
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := Run(ctx, []string{"a", "b"}, Options{}, func(context.Context, string) (int, error) {
+		t.Error("fn called after cancellation")
+		return 0, nil
+	})
+
+	if _, _, skipped := Counts(results); skipped != 2 {
+		t.Errorf("skipped = %d, want 2", skipped)
+	}
+}
+
+func TestReadList(t *testing.T) {
+	t.Parallel()
+
+	in := `
+# portfolio
+example.ch
+example.com   2026-11-02
+  example.ch
+`
+	got, err := ReadList(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"example.ch", "example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadList() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/yannick/infomaniak/internal/audit"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect the local log of changes made through the API",
+	Long: `Inspect the local log of changes made through the API.
+
+Every write request sent to the API is appended to a JSON-lines audit log,
+with the user, profile and command that sent it and its outcome. The log is
+at $XDG_STATE_HOME/infomaniak/audit.log (~/.local/state/infomaniak/audit.log
+by default); set audit_log in the config file or INFOMANIAK_AUDIT_LOG to use
+another path, or to "off" to disable it.`,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+}
+
+// auditLog returns the configured audit log, or nil when it is disabled.
+func auditLog() (*audit.Log, error) {
+	path := viper.GetString("audit_log")
+	switch path {
+	case "off":
+		return nil, nil
+	case "":
+		var err error
+		if path, err = audit.DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+	return audit.New(path), nil
+}
+
+// auditHook returns the infomaniak.WithAudit hook that writes to the
+// audit log, or nil when it is disabled. Failing to write the log does not
+// fail the command since the request has already been sent.
+func auditHook() func(infomaniak.AuditEntry) {
+	log, err := auditLog()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: audit log disabled: %v\n", err)
+		return nil
+	}
+	if log == nil {
+		return nil
+	}
+
+	base := audit.Entry{
+		User:    currentUser(),
+		Profile: viper.GetString("profile"),
+		Command: commandLine(os.Args),
+	}
+	return func(e infomaniak.AuditEntry) {
+		entry := base
+		entry.Time = e.Time.UTC()
+		entry.Method = e.Method
+		entry.Path = e.Path
+		entry.Body = e.Body
+		entry.Status = e.Status
+		if e.Err != nil {
+			entry.Error = e.Err.Error()
+		}
+		if err := log.Append(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// secretFlags are flags whose values never appear in the audit log.
+var secretFlags = []string{"--token", "--auth-code"}
+
+// commandLine joins args as a shell would need them quoted, with the values
+// of secret flags redacted.
+func commandLine(args []string) string {
+	parts := make([]string, len(args))
+	redactNext := false
+	for i, arg := range args {
+		switch {
+		case i == 0:
+			arg = filepath.Base(arg)
+		case redactNext:
+			arg = "<redacted>"
+			redactNext = false
+		default:
+			for _, flag := range secretFlags {
+				if arg == flag {
+					redactNext = true
+				} else if strings.HasPrefix(arg, flag+"=") {
+					arg = flag + "=<redacted>"
+				}
+			}
+		}
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\$`") {
+			arg = strconv.Quote(arg)
+		}
+		parts[i] = arg
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/internal/audit"
+)
+
+var auditLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show the changes recorded in the audit log",
+	Long: `Show the changes recorded in the audit log, oldest first. The full entries,
+including the command line and the request body, are printed with --json.`,
+	Args: cobra.NoArgs,
+	RunE: runAuditLog,
+}
+
+func init() {
+	auditLogCmd.Flags().String("since", "", "only show entries from this period, e.g. 24h or 7d")
+	auditLogCmd.Flags().String("domain", "", "only show entries about this domain")
+
+	auditCmd.AddCommand(auditLogCmd)
+}
+
+func runAuditLog(cmd *cobra.Command, _ []string) error {
+	var filter audit.Filter
+
+	since, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return fmt.Errorf("parse since flag: %w", err)
+	}
+	if since != "" {
+		d, err := parseDuration(since)
+		if err != nil {
+			return fmt.Errorf("parse since flag: %w", err)
+		}
+		filter.Since = time.Now().Add(-d)
+	}
+
+	if filter.Domain, err = cmd.Flags().GetString("domain"); err != nil {
+		return fmt.Errorf("parse domain flag: %w", err)
+	}
+
+	log, err := auditLog()
+	if err != nil {
+		return err
+	}
+	if log == nil {
+		return fmt.Errorf("the audit log is disabled (audit_log: off)")
+	}
+
+	entries, err := audit.Read(log.Path(), filter)
+	if err != nil {
+		return err
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case jsonOut:
+		if entries == nil {
+			entries = []audit.Entry{}
+		}
+		return printJSON(entries)
+	case simple:
+		for _, e := range entries {
+			fmt.Printf("%s %s %s %s %d\n", e.Time.Local().Format(time.RFC3339), e.User, e.Method, e.Path, e.Status)
+		}
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No matching entries.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tUSER\tPROFILE\tREQUEST\tRESULT")
+	for _, e := range entries {
+		result := "-"
+		switch {
+		case e.Error != "":
+			result = e.Error
+		case e.Status != 0:
+			result = strconv.Itoa(e.Status)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s %s\t%s\n",
+			e.Time.Local().Format("2006-01-02 15:04:05"), orDash(e.User), orDash(e.Profile), e.Method, e.Path, result)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/yannick/infomaniak/internal/credentials"
+	"golang.org/x/term"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Store and inspect the API token",
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+}
+
+// currentProfile returns the profile name tokens are stored under.
+func currentProfile() string {
+	if p := viper.GetString("profile"); p != "" {
+		return p
+	}
+	return credentials.DefaultProfile
+}
+
+// openCredentials opens the token store new tokens are saved in. The
+// passphrase for the encrypted file store is taken from
+// $INFOMANIAK_PASSPHRASE or prompted for.
+func openCredentials() (credentials.Store, error) {
+	return credentials.Open(credentialsPassphrase)
+}
+
+// findToken looks for the token of the current profile in every store, so
+// a token saved in the encrypted file is found on hosts with a keyring.
+func findToken() (string, credentials.Store, error) {
+	stores, err := credentials.Stores(credentialsPassphrase)
+	if err != nil {
+		return "", nil, err
+	}
+	return credentials.Lookup(currentProfile(), stores...)
+}
+
+// credentialsPassphrase asks for the passphrase of the credentials file.
+// A new passphrase is asked for twice when typed on a terminal so a typo
+// does not lock the user out.
+func credentialsPassphrase(create bool) (string, error) {
+	if p := os.Getenv("INFOMANIAK_PASSPHRASE"); p != "" {
+		return p, nil
+	}
+
+	prompt := "Passphrase for the credentials file: "
+	if create {
+		prompt = "New passphrase for the credentials file: "
+	}
+	p, err := promptSecret(prompt)
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", fmt.Errorf("passphrase is required to use the credentials file")
+	}
+
+	if create && term.IsTerminal(int(os.Stdin.Fd())) {
+		again, err := promptSecret("Repeat the passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != p {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+	return p, nil
+}
+
+// resolveToken finds the API token and describes where it came from. Flags,
+// environment and config file take precedence over the credential store.
+func resolveToken() (token, source string, err error) {
+	if token := viper.GetString("token"); token != "" {
+		return token, configTokenSource(), nil
+	}
+
+	token, store, err := findToken()
+	if errors.Is(err, credentials.ErrNotFound) {
+		return "", "", fmt.Errorf("token is required: run 'infomaniak auth login' or set via --token, config file, or $INFOMANIAK_TOKEN")
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	return token, store.Name(), nil
+}
+
+func configTokenSource() string {
+	switch {
+	case rootCmd.PersistentFlags().Changed("token"):
+		return "--token flag"
+	case os.Getenv("INFOMANIAK_TOKEN") != "":
+		return "$INFOMANIAK_TOKEN"
+	case viper.GetString("profile") != "" && viper.GetString("profiles."+viper.GetString("profile")+".token") != "":
+		return fmt.Sprintf("profile %s in %s", viper.GetString("profile"), viper.ConfigFileUsed())
+	default:
+		return "config file " + viper.ConfigFileUsed()
+	}
+}
+
+// redactToken keeps just enough of a token to tell tokens apart.
+func redactToken(token string) string {
+	if len(token) <= 8 {
+		return "********"
+	}
+	return token[:4] + "…" + token[len(token)-4:]
+}
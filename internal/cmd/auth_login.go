@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/internal/credentials"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Validate an API token and store it securely",
+	Long: `Validate an API token and store it securely.
+
+The token is read without echo (or from stdin when piped), checked against
+the API and stored for the current profile in the OS keyring. When no keyring
+is available, it is stored in an age-encrypted file protected by a
+passphrase, which is read from $INFOMANIAK_PASSPHRASE or prompted for.`,
+	Args: cobra.NoArgs,
+	RunE: runAuthLogin,
+}
+
+func init() {
+	authLoginCmd.Flags().String("store", "auto", "where to store the token: auto, keyring or file")
+	authLoginCmd.Flags().Bool("no-validate", false, "store the token without checking it against the API")
+
+	authCmd.AddCommand(authLoginCmd)
+}
+
+func runAuthLogin(cmd *cobra.Command, _ []string) error {
+	storeKind, err := cmd.Flags().GetString("store")
+	if err != nil {
+		return fmt.Errorf("parse store flag: %w", err)
+	}
+
+	noValidate, err := cmd.Flags().GetBool("no-validate")
+	if err != nil {
+		return fmt.Errorf("parse no-validate flag: %w", err)
+	}
+
+	store, err := credentialStore(storeKind)
+	if err != nil {
+		return err
+	}
+
+	token, err := promptSecret("Infomaniak API token: ")
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return fmt.Errorf("token must not be empty")
+	}
+
+	if !noValidate {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+		defer cancel()
+
+		if err := validateToken(ctx, newClientWithToken(token)); err != nil {
+			return err
+		}
+	}
+
+	profile := currentProfile()
+	if err := store.Set(profile, token); err != nil {
+		return err
+	}
+
+	fmt.Printf("Token for profile %s stored in %s.\n", profile, store.Name())
+	return nil
+}
+
+func credentialStore(kind string) (credentials.Store, error) {
+	switch kind {
+	case "auto":
+		return openCredentials()
+	case "keyring":
+		kr := credentials.NewKeyring()
+		if !kr.Available() {
+			return nil, fmt.Errorf("OS keyring is not available")
+		}
+		return kr, nil
+	case "file":
+		path, err := credentials.DefaultFilePath()
+		if err != nil {
+			return nil, err
+		}
+		return credentials.NewFile(path, credentialsPassphrase), nil
+	default:
+		return nil, fmt.Errorf("unknown store %q: use auto, keyring or file", kind)
+	}
+}
+
+// validateToken makes a cheap authenticated request to check the token.
+func validateToken(ctx context.Context, client *infomaniak.Client) error {
+	for _, err := range client.IterDomains(ctx, infomaniak.ListOptions{PerPage: 1, Limit: 1}) {
+		if infomaniak.IsUnauthorized(err) {
+			return fmt.Errorf("token rejected by the API: %w", err)
+		}
+		if err != nil {
+			return fmt.Errorf("validate token: %w", err)
+		}
+	}
+	return nil
+}
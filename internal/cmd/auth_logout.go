@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/yannick/infomaniak/internal/credentials"
+)
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the stored token of the current profile",
+	Args:  cobra.NoArgs,
+	RunE:  runAuthLogout,
+}
+
+func init() {
+	authLogoutCmd.Flags().String("store", "auto", "where the token is stored: auto, keyring or file")
+
+	authCmd.AddCommand(authLogoutCmd)
+}
+
+func runAuthLogout(cmd *cobra.Command, _ []string) error {
+	storeKind, err := cmd.Flags().GetString("store")
+	if err != nil {
+		return fmt.Errorf("parse store flag: %w", err)
+	}
+
+	var store credentials.Store
+	if storeKind == "auto" {
+		// Remove the token from wherever it was found, not just from the
+		// store new tokens would be saved in.
+		_, store, err = findToken()
+		if errors.Is(err, credentials.ErrNotFound) {
+			store, err = openCredentials()
+		}
+	} else {
+		store, err = credentialStore(storeKind)
+	}
+	if err != nil {
+		return err
+	}
+
+	profile := currentProfile()
+	err = store.Delete(profile)
+	switch {
+	case errors.Is(err, credentials.ErrNotFound):
+		fmt.Printf("No token stored for profile %s in %s.\n", profile, store.Name())
+	case err != nil:
+		return err
+	default:
+		fmt.Printf("Token for profile %s removed from %s.\n", profile, store.Name())
+	}
+
+	if viper.InConfig("token") || viper.IsSet("profiles."+profile+".token") {
+		fmt.Printf("Note: a plaintext token is still set in %s.\n", viper.ConfigFileUsed())
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which token is used and whether the API accepts it",
+	Args:  cobra.NoArgs,
+	RunE:  runAuthStatus,
+}
+
+func init() {
+	authCmd.AddCommand(authStatusCmd)
+}
+
+func runAuthStatus(cmd *cobra.Command, _ []string) error {
+	token, source, err := resolveToken()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	validErr := validateToken(ctx, newClientWithToken(token))
+
+	status := "valid"
+	if validErr != nil {
+		status = "invalid"
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case jsonOut:
+		if err := printJSON(map[string]string{
+			"profile": currentProfile(),
+			"source":  source,
+			"token":   redactToken(token),
+			"status":  status,
+		}); err != nil {
+			return err
+		}
+	case simple:
+		fmt.Println(status)
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Profile:\t%s\n", currentProfile())
+		fmt.Fprintf(w, "Source:\t%s\n", source)
+		fmt.Fprintf(w, "Token:\t%s\n", redactToken(token))
+		fmt.Fprintf(w, "Status:\t%s\n", status)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return validErr
+}
@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/internal/bulk"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+// addBulkFlags registers the flags of commands that act on many domains.
+func addBulkFlags(cmd *cobra.Command) {
+	cmd.Flags().String("from-file", "", "read domains from this file, one per line (- for stdin)")
+	cmd.Flags().Int("parallel", 4, "number of domains processed at once")
+	cmd.Flags().Bool("continue-on-error", false, "keep going after a domain fails")
+}
+
+// domainArgs collects the domains named on the command line, in --from-file
+// and, for a "-" argument, on stdin.
+func domainArgs(cmd *cobra.Command, args []string) ([]string, error) {
+	fromFile, err := cmd.Flags().GetString("from-file")
+	if err != nil {
+		return nil, fmt.Errorf("parse from-file flag: %w", err)
+	}
+
+	if fromFile == "-" && slices.Contains(args, "-") {
+		return nil, fmt.Errorf("stdin can only be read once: pass - or --from-file -, not both")
+	}
+
+	var domains []string
+	seen := map[string]bool{}
+	add := func(names ...string) {
+		for _, n := range names {
+			if !seen[n] {
+				seen[n] = true
+				domains = append(domains, n)
+			}
+		}
+	}
+
+	for _, arg := range args {
+		if arg != "-" {
+			add(arg)
+			continue
+		}
+		names, err := bulk.ReadList(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("read domains from stdin: %w", err)
+		}
+		add(names...)
+	}
+
+	if fromFile != "" {
+		names, err := readDomainFile(fromFile)
+		if err != nil {
+			return nil, err
+		}
+		add(names...)
+	}
+
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("no domains given: name them, use --from-file, or pass - to read them from stdin")
+	}
+	return domains, nil
+}
+
+// readsStdin reports whether domainArgs takes the domains from stdin, which
+// leaves nothing there to answer a confirmation prompt.
+func readsStdin(cmd *cobra.Command, args []string) bool {
+	fromFile, _ := cmd.Flags().GetString("from-file")
+	return fromFile == "-" || slices.Contains(args, "-")
+}
+
+func readDomainFile(path string) ([]string, error) {
+	var r io.Reader = stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("open domain list: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	names, err := bulk.ReadList(r)
+	if err != nil {
+		return nil, fmt.Errorf("read domains from %s: %w", path, err)
+	}
+	return names, nil
+}
+
+// domainOutcome is what a bulk operation reports for one domain: data for
+// --json output and a message for humans.
+type domainOutcome struct {
+	data    any
+	message string
+}
+
+// bulkResult is the JSON form of a domain's outcome in a bulk run.
+type bulkResult struct {
+	Domain string `json:"domain"`
+	Status string `json:"status"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// runDomains applies fn to every domain with the worker pool configured by
+// addBulkFlags. Each call gets its own 30 second timeout. A single domain
+// behaves like a plain command: its message or data is printed and its
+// error returned. Several domains end with a summary table and a non-zero
+// exit status if any of them failed: the failure's own exit status when all
+// failures are of the same kind, exitBulkFailed otherwise.
+func runDomains(cmd *cobra.Command, domains []string, fn func(ctx context.Context, domain string) (domainOutcome, error)) error {
+	parallel, err := cmd.Flags().GetInt("parallel")
+	if err != nil {
+		return fmt.Errorf("parse parallel flag: %w", err)
+	}
+	if parallel < 1 {
+		return fmt.Errorf("parallel must be at least 1, got %d", parallel)
+	}
+
+	continueOnError, err := cmd.Flags().GetBool("continue-on-error")
+	if err != nil {
+		return fmt.Errorf("parse continue-on-error flag: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+
+	call := func(ctx context.Context, domain string) (domainOutcome, error) {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		outcome, err := fn(ctx, domain)
+		if errors.Is(err, infomaniak.ErrDryRun) {
+			return domainOutcome{
+				data: map[string]string{
+					"domain": domain,
+					"status": "dry-run",
+				},
+				message: fmt.Sprintf("Dry run: no changes made to %s.", domain),
+			}, nil
+		}
+		return outcome, err
+	}
+
+	if len(domains) == 1 {
+		outcome, err := call(cmd.Context(), domains[0])
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			return printJSON(outcome.data)
+		}
+		fmt.Println(outcome.message)
+		return nil
+	}
+
+	results := bulk.Run(cmd.Context(), domains, bulk.Options{
+		Parallel:        parallel,
+		ContinueOnError: continueOnError,
+	}, call)
+
+	ok, failed, skipped := bulk.Counts(results)
+
+	if jsonOut {
+		out := make([]bulkResult, len(results))
+		for i, r := range results {
+			out[i] = bulkResult{Domain: r.Item, Status: "ok", Result: r.Value.data}
+			switch {
+			case r.Skipped:
+				out[i].Status = "skipped"
+			case r.Err != nil:
+				out[i].Status = "failed"
+				out[i].Error = r.Err.Error()
+			}
+		}
+		if err := printJSON(out); err != nil {
+			return err
+		}
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DOMAIN\tRESULT\tDETAIL")
+		for _, r := range results {
+			switch {
+			case r.Skipped:
+				fmt.Fprintf(w, "%s\tskipped\t-\n", r.Item)
+			case r.Err != nil:
+				fmt.Fprintf(w, "%s\tfailed\t%v\n", r.Item, r.Err)
+			default:
+				fmt.Fprintf(w, "%s\tok\t%s\n", r.Item, r.Value.message)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Printf("\n%d succeeded, %d failed, %d skipped.\n", ok, failed, skipped)
+	}
+
+	if failed > 0 || skipped > 0 {
+		if !continueOnError && skipped > 0 && !jsonOut {
+			fmt.Fprintln(os.Stderr, "Stopped after the first failure; use --continue-on-error to process the remaining domains.")
+		}
+		// The summary already names the failures; usage would only bury it.
+		cmd.SilenceUsage = true
+		if err := commonFailure(results); err != nil {
+			return fmt.Errorf("%d of %d domains failed: %w", failed, len(results), err)
+		}
+		return &ExitError{Code: exitBulkFailed}
+	}
+	return nil
+}
+
+// exitBulkFailed is the exit status of a bulk run in which domains failed
+// for different reasons.
+const exitBulkFailed = 1
+
+// commonFailure returns the first failure of a bulk run when every failure
+// is the same kind of API error, so the exit status can tell it apart, and
+// nil otherwise.
+func commonFailure(results []bulk.Result[domainOutcome]) error {
+	var first error
+	for _, r := range results {
+		if r.Err == nil {
+			continue
+		}
+		if apiErrorKind(r.Err) == "" {
+			return nil
+		}
+		if first == nil {
+			first = r.Err
+		} else if apiErrorKind(r.Err) != apiErrorKind(first) {
+			return nil
+		}
+	}
+	return first
+}
+
+// apiErrorKind names the kinds of API errors that have their own exit
+// status, and returns "" for any other error.
+func apiErrorKind(err error) string {
+	switch {
+	case infomaniak.IsUnauthorized(err):
+		return "unauthorized"
+	case infomaniak.IsNotFound(err):
+		return "not found"
+	case infomaniak.IsValidation(err):
+		return "validation"
+	case infomaniak.IsRateLimited(err):
+		return "rate limited"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/spf13/viper"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+// newClient builds an API client from the resolved configuration, falling
+// back to the token stored by "auth login".
+func newClient() (*infomaniak.Client, error) {
+	token, _, err := resolveToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return newClientWithToken(token), nil
+}
+
+func newClientWithToken(token string) *infomaniak.Client {
+	retry := infomaniak.DefaultRetryPolicy
+	// --retries counts the attempts after the first one.
+	retry.MaxAttempts = viper.GetInt("retries") + 1
+
+	opts := []infomaniak.Option{
+		infomaniak.WithBaseURL(viper.GetString("base_url")),
+		infomaniak.WithAccountID(viper.GetString("account_id")),
+		infomaniak.WithUserAgent("infomaniak-cli/" + version),
+		infomaniak.WithRetryPolicy(retry),
+		infomaniak.WithRateLimit(viper.GetFloat64("rate_limit"), 0),
+		infomaniak.WithTransport(httpTransport()),
+		infomaniak.WithAudit(auditHook()),
+	}
+	if dryRun() {
+		opts = append(opts, infomaniak.WithDryRun(dryRunOutput()))
+	}
+
+	return infomaniak.New(token, opts...)
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v any) error {
+	return writeJSON(os.Stdout, v)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
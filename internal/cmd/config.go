@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/yannick/infomaniak/internal/config"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the infomaniak config file",
+}
+
+var configProfilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "Manage named profiles for multiple accounts",
+}
+
+func init() {
+	configCmd.AddCommand(configProfilesCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+// loadConfigFile opens the config file in use, falling back to the default
+// location when none was found.
+func loadConfigFile() (*config.File, error) {
+	path := rootCmd.PersistentFlags().Lookup("config").Value.String()
+	if path == "" {
+		path = viper.ConfigFileUsed()
+	}
+	if path == "" {
+		var err error
+		if path, err = config.DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+	return config.Load(path)
+}
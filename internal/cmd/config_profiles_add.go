@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/internal/config"
+)
+
+var configProfilesAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or update a profile in the config file",
+	Long: `Add or update a profile in the config file.
+
+The profile takes the values of --token, --account-id and --base-url. Only
+the given flags are written, so an existing profile can be updated one
+setting at a time. The config file is saved with 0600 permissions.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigProfilesAdd,
+}
+
+func init() {
+	configProfilesAddCmd.Flags().String("base-url", "", "API base URL for the profile")
+	configProfilesAddCmd.Flags().Bool("use", false, "also select the profile as default")
+
+	configProfilesCmd.AddCommand(configProfilesAddCmd)
+}
+
+func runConfigProfilesAdd(cmd *cobra.Command, args []string) error {
+	var p config.Profile
+	var err error
+	if p.Token, err = cmd.Flags().GetString("token"); err != nil {
+		return fmt.Errorf("parse token flag: %w", err)
+	}
+	if p.AccountID, err = cmd.Flags().GetString("account-id"); err != nil {
+		return fmt.Errorf("parse account-id flag: %w", err)
+	}
+	if p.BaseURL, err = cmd.Flags().GetString("base-url"); err != nil {
+		return fmt.Errorf("parse base-url flag: %w", err)
+	}
+
+	use, err := cmd.Flags().GetBool("use")
+	if err != nil {
+		return fmt.Errorf("parse use flag: %w", err)
+	}
+
+	f, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	f.SetProfile(args[0], p)
+	if use {
+		if err := f.SetActive(args[0]); err != nil {
+			return err
+		}
+	}
+	if err := f.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Profile %s saved to %s.\n", args[0], f.Path())
+	return nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var configProfilesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the profiles defined in the config file",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigProfilesList,
+}
+
+func init() {
+	configProfilesCmd.AddCommand(configProfilesListCmd)
+}
+
+func runConfigProfilesList(cmd *cobra.Command, _ []string) error {
+	f, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	profiles := f.Profiles()
+	active := viper.GetString("profile")
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case jsonOut:
+		type entry struct {
+			Name      string `json:"name"`
+			Active    bool   `json:"active"`
+			AccountID string `json:"account_id,omitempty"`
+			BaseURL   string `json:"base_url,omitempty"`
+			HasToken  bool   `json:"has_token"`
+		}
+		out := []entry{}
+		for _, name := range f.ProfileNames() {
+			p := profiles[name]
+			out = append(out, entry{
+				Name:      name,
+				Active:    name == active,
+				AccountID: p.AccountID,
+				BaseURL:   p.BaseURL,
+				HasToken:  p.Token != "",
+			})
+		}
+		return printJSON(out)
+	case simple:
+		for _, name := range f.ProfileNames() {
+			fmt.Println(name)
+		}
+		return nil
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ACTIVE\tNAME\tACCOUNT ID\tBASE URL\tTOKEN")
+		for _, name := range f.ProfileNames() {
+			p := profiles[name]
+			marker := ""
+			if name == active {
+				marker = "*"
+			}
+			token := "-"
+			if p.Token != "" {
+				token = "set"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", marker, name, orDash(p.AccountID), orDash(p.BaseURL), token)
+		}
+		return w.Flush()
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
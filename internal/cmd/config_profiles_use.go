@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var configProfilesUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Select the profile used when --profile is not given",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigProfilesUse,
+}
+
+func init() {
+	configProfilesCmd.AddCommand(configProfilesUseCmd)
+}
+
+func runConfigProfilesUse(_ *cobra.Command, args []string) error {
+	f, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	if err := f.SetActive(args[0]); err != nil {
+		return err
+	}
+	if err := f.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Now using profile %s (%s).\n", args[0], f.Path())
+	return nil
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/spf13/viper"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+// verbosity returns how much HTTP traffic to log: 0 nothing, 1 requests
+// and responses with their status and timing, 2 headers and bodies as well.
+// --debug is the highest level.
+func verbosity() int {
+	if viper.GetBool("debug") {
+		return 2
+	}
+	return viper.GetInt("verbose")
+}
+
+// setupLogging sends log output to stderr at the level --verbose and
+// --debug ask for. Without them the default logger is left alone.
+func setupLogging() {
+	if verbosity() == 0 {
+		return
+	}
+
+	level := slog.LevelInfo
+	if verbosity() >= 2 {
+		level = slog.LevelDebug
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+}
+
+// httpTransport returns the transport API clients use: a logging one when
+// -v or --debug is given, otherwise nil for the default.
+func httpTransport() http.RoundTripper {
+	if verbosity() == 0 {
+		return nil
+	}
+	return &infomaniak.LoggingTransport{Logger: slog.Default()}
+}
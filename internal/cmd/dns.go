@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var dnsCmd = &cobra.Command{
+	Use:   "dns",
+	Short: "Manage DNS zones hosted at Infomaniak",
+}
+
+func init() {
+	rootCmd.AddCommand(dnsCmd)
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/internal/zone"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var dnsApplyCmd = &cobra.Command{
+	Use:   "apply [domain] -f <zone-file>",
+	Short: "Reconcile a zone with a desired-state YAML/JSON file",
+	Long: `Reconcile a zone with a desired-state YAML/JSON file.
+
+The live records are fetched, compared with the file and the resulting plan is
+printed. Changes are only applied after confirmation or with --auto-approve.
+Records missing from the file are left untouched unless --prune is given.
+
+The domain is taken from the argument or, if omitted, from the file's
+"domain" key. With --json the plan is printed as JSON without prompting;
+combine it with --auto-approve to apply the plan non-interactively.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDNSApply,
+}
+
+func init() {
+	dnsApplyCmd.Flags().StringP("file", "f", "", "path to the zone file")
+	dnsApplyCmd.Flags().Bool("prune", false, "delete live records that are not in the file")
+	dnsApplyCmd.Flags().Bool("auto-approve", false, "apply the plan without asking for confirmation")
+	_ = dnsApplyCmd.MarkFlagRequired("file")
+
+	dnsCmd.AddCommand(dnsApplyCmd)
+}
+
+func runDNSApply(cmd *cobra.Command, args []string) error {
+	path, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("parse file flag: %w", err)
+	}
+
+	prune, err := cmd.Flags().GetBool("prune")
+	if err != nil {
+		return fmt.Errorf("parse prune flag: %w", err)
+	}
+
+	autoApprove, err := cmd.Flags().GetBool("auto-approve")
+	if err != nil {
+		return fmt.Errorf("parse auto-approve flag: %w", err)
+	}
+
+	file, err := zone.Load(path)
+	if err != nil {
+		return err
+	}
+
+	domain := file.Domain
+	if len(args) == 1 {
+		domain = args[0]
+	}
+	if domain == "" {
+		return fmt.Errorf("domain is required: pass it as argument or set \"domain\" in %s", path)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+
+	live, err := client.ListRecords(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("apply zone: %w", err)
+	}
+
+	plan := zone.Compute(live, file.Desired(), prune)
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+
+	if jsonOut && !autoApprove {
+		return printJSON(plan)
+	}
+
+	if !jsonOut {
+		fmt.Printf("Zone %s:\n\n", domain)
+		plan.Render(os.Stdout)
+	}
+
+	if plan.Empty() {
+		if !jsonOut {
+			fmt.Println("\nNo changes. The zone matches the file.")
+		}
+		return nil
+	}
+
+	if !autoApprove {
+		ok, err := confirm(fmt.Sprintf("\nDo you want to apply these changes to %s?", domain))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("apply cancelled")
+		}
+	}
+
+	if err := applyPlan(ctx, client, domain, plan); err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return printJSON(plan)
+	}
+
+	fmt.Printf("\nApply complete! Resources: %d added, %d changed, %d destroyed.\n",
+		plan.Count(zone.ActionCreate), plan.Count(zone.ActionUpdate), plan.Count(zone.ActionDelete))
+	return nil
+}
+
+// applyPlan executes the changes in order. In dry-run mode every change is
+// described before infomaniak.ErrDryRun is returned.
+func applyPlan(ctx context.Context, client *infomaniak.Client, domain string, plan zone.Plan) error {
+	dry := false
+	for _, c := range plan.Changes {
+		var err error
+		switch c.Action {
+		case zone.ActionCreate:
+			_, err = client.CreateRecord(ctx, domain, *c.Desired)
+		case zone.ActionUpdate:
+			_, err = client.UpdateRecord(ctx, domain, c.Current.ID, *c.Desired)
+		case zone.ActionDelete:
+			err = client.DeleteRecord(ctx, domain, c.Current.ID)
+		}
+		if errors.Is(err, infomaniak.ErrDryRun) {
+			dry = true
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("apply zone: %w", err)
+		}
+	}
+	if dry {
+		return fmt.Errorf("apply zone: %w", infomaniak.ErrDryRun)
+	}
+	return nil
+}
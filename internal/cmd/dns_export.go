@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/internal/zone"
+	"go.yaml.in/yaml/v3"
+)
+
+var dnsExportCmd = &cobra.Command{
+	Use:   "export <domain>",
+	Short: "Export the records of a zone as a BIND master file or YAML",
+	Long: `Export the records of a zone.
+
+The default "bind" format is an RFC 1035 master file with $ORIGIN and $TTL.
+The "yaml" format can be fed back into "dns apply". Records are sorted so
+that exports of an unchanged zone diff cleanly.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDNSExport,
+}
+
+func init() {
+	dnsExportCmd.Flags().String("format", "bind", "output format: bind or yaml")
+	dnsExportCmd.Flags().StringP("output", "o", "", "write to file instead of stdout")
+
+	dnsCmd.AddCommand(dnsExportCmd)
+}
+
+func runDNSExport(cmd *cobra.Command, args []string) error {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("parse format flag: %w", err)
+	}
+	if format != "bind" && format != "yaml" {
+		return fmt.Errorf("unsupported format %q: use bind or yaml", format)
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("parse output flag: %w", err)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	records, err := client.ListRecords(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("export zone: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+
+	var buf bytes.Buffer
+	switch {
+	case jsonOut:
+		err = writeJSON(&buf, records)
+	case format == "yaml":
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err = enc.Encode(zone.FromRecords(args[0], records)); err == nil {
+			err = enc.Close()
+		}
+	default:
+		err = zone.WriteBIND(&buf, args[0], records)
+	}
+	if err != nil {
+		return fmt.Errorf("encode zone: %w", err)
+	}
+
+	if output == "" {
+		_, err = buf.WriteTo(os.Stdout)
+		return err
+	}
+
+	if err := os.WriteFile(output, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write output file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/internal/zone"
+)
+
+var dnsImportCmd = &cobra.Command{
+	Use:   "import <domain> <zone-file>",
+	Short: "Create missing records from a BIND master file",
+	Long: `Create missing records from a BIND master file.
+
+Every record in the file that does not already exist in the zone with the
+same name, type and value is created. Existing records are never changed or
+deleted. SOA records and NS records at the zone apex are skipped.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDNSImport,
+}
+
+func init() {
+	dnsImportCmd.Flags().Bool("auto-approve", false, "create the records without asking for confirmation")
+
+	dnsCmd.AddCommand(dnsImportCmd)
+}
+
+func runDNSImport(cmd *cobra.Command, args []string) error {
+	autoApprove, err := cmd.Flags().GetBool("auto-approve")
+	if err != nil {
+		return fmt.Errorf("parse auto-approve flag: %w", err)
+	}
+
+	f, err := os.Open(args[1])
+	if err != nil {
+		return fmt.Errorf("open zone file: %w", err)
+	}
+	defer f.Close()
+
+	desired, err := zone.ParseBIND(f, args[0])
+	if err != nil {
+		return fmt.Errorf("parse zone file %s: %w", args[1], err)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+
+	live, err := client.ListRecords(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("import zone: %w", err)
+	}
+
+	plan := zone.Missing(live, desired)
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+
+	if jsonOut && !autoApprove {
+		return printJSON(plan)
+	}
+
+	if !jsonOut {
+		fmt.Printf("Zone %s:\n\n", args[0])
+		plan.Render(os.Stdout)
+	}
+
+	if plan.Empty() {
+		if !jsonOut {
+			fmt.Println("\nNothing to import. All records already exist.")
+		}
+		return nil
+	}
+
+	if !autoApprove {
+		ok, err := confirm(fmt.Sprintf("\nDo you want to create these records in %s?", args[0]))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("import cancelled")
+		}
+	}
+
+	if err := applyPlan(ctx, client, args[0], plan); err != nil {
+		return err
+	}
+
+	if jsonOut {
+		return printJSON(plan)
+	}
+
+	fmt.Printf("\nImport complete! %d records created.\n", plan.Count(zone.ActionCreate))
+	return nil
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var dnsRecordsCmd = &cobra.Command{
+	Use:   "records",
+	Short: "Manage DNS records of a zone",
+}
+
+func init() {
+	dnsCmd.AddCommand(dnsRecordsCmd)
+}
+
+// addRecordFlags registers the flags shared by commands that write a record.
+func addRecordFlags(cmd *cobra.Command) {
+	cmd.Flags().String("type", "", "record type (A, AAAA, CNAME, MX, TXT, SRV, CAA, ...)")
+	cmd.Flags().String("source", "", "record name relative to the zone, \".\" for the apex")
+	cmd.Flags().String("target", "", "record value")
+	cmd.Flags().Int("ttl", 3600, "time to live in seconds")
+	cmd.Flags().Int("priority", 0, "priority for MX and SRV records")
+	cmd.Flags().Int("weight", 0, "weight for SRV records")
+	cmd.Flags().Int("port", 0, "port for SRV records")
+}
+
+// applyRecordFlags overlays the record flags that were set on cmd onto input.
+// Unset flags keep the existing value, or the flag default when input has none.
+func applyRecordFlags(cmd *cobra.Command, input *infomaniak.RecordInput) error {
+	flags := cmd.Flags()
+
+	strFlags := map[string]*string{
+		"type":   &input.Type,
+		"source": &input.Source,
+		"target": &input.Target,
+	}
+	for name, dst := range strFlags {
+		if !flags.Changed(name) {
+			continue
+		}
+		v, err := flags.GetString(name)
+		if err != nil {
+			return fmt.Errorf("parse %s flag: %w", name, err)
+		}
+		*dst = v
+	}
+
+	intFlags := map[string]*int{
+		"ttl":      &input.TTL,
+		"priority": &input.Priority,
+		"weight":   &input.Weight,
+		"port":     &input.Port,
+	}
+	for name, dst := range intFlags {
+		if !flags.Changed(name) && *dst != 0 {
+			continue
+		}
+		v, err := flags.GetInt(name)
+		if err != nil {
+			return fmt.Errorf("parse %s flag: %w", name, err)
+		}
+		*dst = v
+	}
+
+	input.Type = strings.ToUpper(input.Type)
+	return nil
+}
+
+func parseRecordID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid record id %q", s)
+	}
+	return id, nil
+}
+
+func findRecord(records []infomaniak.Record, id int) (*infomaniak.Record, error) {
+	for i := range records {
+		if records[i].ID == id {
+			return &records[i], nil
+		}
+	}
+	return nil, fmt.Errorf("record %d not found", id)
+}
+
+// recordInputFrom converts an existing record into an input for updates.
+func recordInputFrom(r infomaniak.Record) infomaniak.RecordInput {
+	return infomaniak.RecordInput{
+		Source:   r.Source,
+		Type:     r.Type,
+		TTL:      r.TTL,
+		Target:   r.Target,
+		Priority: r.Priority,
+		Weight:   r.Weight,
+		Port:     r.Port,
+	}
+}
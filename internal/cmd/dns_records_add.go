@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var dnsRecordsAddCmd = &cobra.Command{
+	Use:   "add <domain>",
+	Short: "Add a DNS record to a zone",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDNSRecordsAdd,
+}
+
+func init() {
+	addRecordFlags(dnsRecordsAddCmd)
+	addWaitFlags(dnsRecordsAddCmd)
+	addResolverFlag(dnsRecordsAddCmd)
+	_ = dnsRecordsAddCmd.MarkFlagRequired("type")
+	_ = dnsRecordsAddCmd.MarkFlagRequired("target")
+
+	dnsRecordsCmd.AddCommand(dnsRecordsAddCmd)
+}
+
+func runDNSRecordsAdd(cmd *cobra.Command, args []string) error {
+	input := infomaniak.RecordInput{Source: "."}
+	if err := applyRecordFlags(cmd, &input); err != nil {
+		return err
+	}
+
+	wait, err := recordWait(cmd, args[0], input, false)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	record, err := client.CreateRecord(ctx, args[0], input)
+	if err != nil {
+		return fmt.Errorf("add record: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case jsonOut:
+		err = printJSON(record)
+	case simple:
+		fmt.Println(record.ID)
+	default:
+		fmt.Printf("Record %d (%s %s) added to %s.\n", record.ID, record.Type, record.Source, args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	return wait()
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var dnsRecordsDeleteCmd = &cobra.Command{
+	Use:   "delete <domain> <record-id>",
+	Short: "Delete a DNS record from a zone",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runDNSRecordsDelete,
+}
+
+func init() {
+	addWaitFlags(dnsRecordsDeleteCmd)
+	addResolverFlag(dnsRecordsDeleteCmd)
+
+	dnsRecordsCmd.AddCommand(dnsRecordsDeleteCmd)
+}
+
+func runDNSRecordsDelete(cmd *cobra.Command, args []string) error {
+	id, err := parseRecordID(args[1])
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	// Waiting needs to know what the record was before it is gone.
+	wait := func() error { return nil }
+	if w, _ := cmd.Flags().GetBool("wait"); w {
+		records, err := client.ListRecords(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("delete record: %w", err)
+		}
+		current, err := findRecord(records, id)
+		if err != nil {
+			return fmt.Errorf("delete record: %w in %s", err, args[0])
+		}
+		if wait, err = recordWait(cmd, args[0], recordInputFrom(*current), true); err != nil {
+			return err
+		}
+	}
+
+	if err := client.DeleteRecord(ctx, args[0], id); err != nil {
+		return fmt.Errorf("delete record: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+
+	if jsonOut {
+		if err := printJSON(map[string]any{
+			"domain": args[0],
+			"id":     id,
+			"status": "deleted",
+		}); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("Record %d deleted from %s.\n", id, args[0])
+	}
+
+	return wait()
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var dnsRecordsListCmd = &cobra.Command{
+	Use:   "list <domain>",
+	Short: "List DNS records of a zone",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDNSRecordsList,
+}
+
+func init() {
+	dnsRecordsListCmd.Flags().String("type", "", "only show records of this type")
+
+	dnsRecordsCmd.AddCommand(dnsRecordsListCmd)
+}
+
+func runDNSRecordsList(cmd *cobra.Command, args []string) error {
+	recordType, err := cmd.Flags().GetString("type")
+	if err != nil {
+		return fmt.Errorf("parse type flag: %w", err)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	records, err := client.ListRecords(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("list records: %w", err)
+	}
+
+	if recordType != "" {
+		filtered := records[:0]
+		for _, r := range records {
+			if strings.EqualFold(r.Type, recordType) {
+				filtered = append(filtered, r)
+			}
+		}
+		records = filtered
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case jsonOut:
+		return printJSON(records)
+	case simple:
+		for _, r := range records {
+			fmt.Printf("%s %d %s %s\n", r.Source, r.TTL, r.Type, r.Target)
+		}
+		return nil
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tSOURCE\tTYPE\tTTL\tPRIORITY\tTARGET")
+		for _, r := range records {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%s\n", r.ID, r.Source, r.Type, r.TTL, recordPriority(r), r.Target)
+		}
+		return w.Flush()
+	}
+}
+
+// recordPriority renders the priority, weight and port columns compactly.
+func recordPriority(r infomaniak.Record) string {
+	switch r.Type {
+	case "MX":
+		return fmt.Sprintf("%d", r.Priority)
+	case "SRV":
+		return fmt.Sprintf("%d %d %d", r.Priority, r.Weight, r.Port)
+	default:
+		return "-"
+	}
+}
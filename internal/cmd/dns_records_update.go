@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var dnsRecordsUpdateCmd = &cobra.Command{
+	Use:   "update <domain> <record-id>",
+	Short: "Update a DNS record in a zone",
+	Long:  "Update a DNS record in a zone. Only the flags that are given are changed; all other fields keep their current value.",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runDNSRecordsUpdate,
+}
+
+func init() {
+	addRecordFlags(dnsRecordsUpdateCmd)
+	addWaitFlags(dnsRecordsUpdateCmd)
+	addResolverFlag(dnsRecordsUpdateCmd)
+
+	dnsRecordsCmd.AddCommand(dnsRecordsUpdateCmd)
+}
+
+func runDNSRecordsUpdate(cmd *cobra.Command, args []string) error {
+	id, err := parseRecordID(args[1])
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	records, err := client.ListRecords(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("update record: %w", err)
+	}
+
+	current, err := findRecord(records, id)
+	if err != nil {
+		return fmt.Errorf("update record: %w in %s", err, args[0])
+	}
+
+	input := recordInputFrom(*current)
+	if err := applyRecordFlags(cmd, &input); err != nil {
+		return err
+	}
+
+	wait, err := recordWait(cmd, args[0], input, false)
+	if err != nil {
+		return err
+	}
+
+	record, err := client.UpdateRecord(ctx, args[0], id, input)
+	if err != nil {
+		return fmt.Errorf("update record: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case jsonOut:
+		err = printJSON(record)
+	case simple:
+		fmt.Println(record.ID)
+	default:
+		fmt.Printf("Record %d in %s updated successfully.\n", id, args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	return wait()
+}
@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/internal/dnscheck"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var dnsWaitCmd = &cobra.Command{
+	Use:   "wait <domain>",
+	Short: "Wait until a DNS change is visible on all nameservers",
+	Long: `Wait until a DNS change is visible on all nameservers.
+
+The servers of the parent zone (the TLD) and the authoritative nameservers
+are queried directly every --interval until they all show the change, or
+until --timeout elapses, in which case the command fails.
+
+--record waits for a record to be published, --absent for one to be
+removed. Records are written as "name type [value]" with the name relative
+to the zone ("@" for the apex); without a value any record of that type
+matches. --nameservers waits for the parent zone to delegate to exactly these
+nameservers. Without any of them, the command waits until the zone is served
+by the nameservers it is delegated to.`,
+	Example: `  infomaniak dns wait example.ch --record "www A 192.0.2.1"
+  infomaniak dns wait example.ch --record "@ MX 10 mail.example.ch" --absent "old CNAME"
+  infomaniak dns wait example.ch --nameservers ns1.example.net,ns2.example.net`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDNSWait,
+	// Pipelines block on the exit status; usage output would only add noise.
+	SilenceUsage: true,
+}
+
+func init() {
+	dnsWaitCmd.Flags().StringArray("record", nil, `record that must be published, as "name type [value]" (repeatable)`)
+	dnsWaitCmd.Flags().StringArray("absent", nil, `record that must be gone, as "name type [value]" (repeatable)`)
+	dnsWaitCmd.Flags().StringSlice("nameservers", nil, "nameservers the parent zone must delegate to")
+	dnsWaitCmd.Flags().Duration("interval", 10*time.Second, "polling interval")
+	dnsWaitCmd.Flags().Duration("timeout", 30*time.Minute, "give up waiting after this long")
+	addResolverFlag(dnsWaitCmd)
+
+	dnsCmd.AddCommand(dnsWaitCmd)
+}
+
+func runDNSWait(cmd *cobra.Command, args []string) error {
+	watch := dnscheck.Watch{Zone: args[0]}
+
+	for _, flag := range []string{"record", "absent"} {
+		specs, err := cmd.Flags().GetStringArray(flag)
+		if err != nil {
+			return fmt.Errorf("parse %s flag: %w", flag, err)
+		}
+		for _, spec := range specs {
+			r, err := parseRecordSpec(args[0], spec)
+			if err != nil {
+				return fmt.Errorf("parse %s flag: %w", flag, err)
+			}
+			r.Absent = flag == "absent"
+			watch.Records = append(watch.Records, r)
+		}
+	}
+
+	var err error
+	if watch.Nameservers, err = cmd.Flags().GetStringSlice("nameservers"); err != nil {
+		return fmt.Errorf("parse nameservers flag: %w", err)
+	}
+
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		return fmt.Errorf("parse interval flag: %w", err)
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return fmt.Errorf("parse timeout flag: %w", err)
+	}
+
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", interval)
+	}
+
+	checker, err := nameserverChecker(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	defer cancel()
+
+	progress, waitErr := waitForPropagation(ctx, cmd, checker, watch, interval)
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case progress == nil:
+	case jsonOut:
+		if err := printJSON(progress); err != nil {
+			return err
+		}
+	case simple:
+		for _, s := range progress.Servers {
+			fmt.Printf("%s %s %s\n", s.Nameserver, orUnset(s.Address), stateName(s))
+		}
+	default:
+		if waitErr == nil {
+			fmt.Printf("DNS change for %s is visible on all %d servers.\n", progress.Zone, len(progress.Servers))
+			break
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ROLE\tNAMESERVER\tADDRESS\tSTATE\tDETAIL")
+		for _, s := range progress.Servers {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", s.Role, s.Nameserver, s.Address, stateName(s), s.Detail)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return waitErr
+}
+
+// addResolverFlag registers --resolver on commands that query DNS servers.
+func addResolverFlag(cmd *cobra.Command) {
+	cmd.Flags().String("resolver", "", "resolver (host or host:port) used to look up nameservers and their addresses")
+}
+
+// addWaitFlags registers the flags of commands that can wait for their
+// change to become visible in DNS.
+func addWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("wait", false, "wait until the change is visible on all nameservers")
+	cmd.Flags().Duration("wait-interval", 10*time.Second, "polling interval for --wait")
+	cmd.Flags().Duration("wait-timeout", 30*time.Minute, "give up waiting after this long")
+}
+
+// waitFlags returns whether --wait was given, with its interval and timeout.
+// Dry runs change nothing, so there is nothing to wait for. The flags are
+// checked here so a bad value is rejected before anything is changed.
+func waitFlags(cmd *cobra.Command) (wait bool, interval, timeout time.Duration, err error) {
+	if wait, err = cmd.Flags().GetBool("wait"); err != nil {
+		return false, 0, 0, fmt.Errorf("parse wait flag: %w", err)
+	}
+	if interval, err = cmd.Flags().GetDuration("wait-interval"); err != nil {
+		return false, 0, 0, fmt.Errorf("parse wait-interval flag: %w", err)
+	}
+	if timeout, err = cmd.Flags().GetDuration("wait-timeout"); err != nil {
+		return false, 0, 0, fmt.Errorf("parse wait-timeout flag: %w", err)
+	}
+	if wait && interval <= 0 {
+		return false, 0, 0, fmt.Errorf("wait-interval must be positive, got %s", interval)
+	}
+	return wait && !dryRun(), interval, timeout, nil
+}
+
+// waitForPropagation polls every interval until watch is visible on every
+// server or ctx is done. Servers are reported on stderr as their state
+// changes. The last progress is returned along with the error.
+func waitForPropagation(ctx context.Context, cmd *cobra.Command, checker *dnscheck.Client, watch dnscheck.Watch, interval time.Duration) (*dnscheck.Progress, error) {
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+	quiet := jsonOut || simple
+
+	var (
+		progress *dnscheck.Progress
+		lastErr  error
+		seen     = map[string]string{}
+	)
+	for {
+		p, err := checker.Propagation(ctx, watch)
+		switch {
+		case err != nil:
+			if !quiet && (lastErr == nil || err.Error() != lastErr.Error()) {
+				fmt.Fprintf(os.Stderr, "%s  %v\n", time.Now().Format(time.TimeOnly), err)
+			}
+			lastErr = err
+		default:
+			progress, lastErr = p, nil
+			for _, s := range p.Servers {
+				key := s.Role + " " + s.Nameserver + " " + s.Address
+				line := fmt.Sprintf("%-7s  %s %s: %s", stateName(s), s.Role, serverLabel(s), s.Detail)
+				if !quiet && seen[key] != line {
+					fmt.Fprintf(os.Stderr, "%s  %s\n", time.Now().Format(time.TimeOnly), line)
+				}
+				seen[key] = line
+			}
+			if p.Done() {
+				return p, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if progress == nil {
+				return nil, fmt.Errorf("gave up waiting for %s: %w", watch.Zone, lastErr)
+			}
+			return progress, fmt.Errorf("gave up waiting for %s: %d of %d servers do not show the change yet",
+				progress.Zone, progress.Pending(), len(progress.Servers))
+		case <-time.After(interval):
+		}
+	}
+}
+
+func stateName(s dnscheck.ServerState) string {
+	if s.Done {
+		return "ok"
+	}
+	return "pending"
+}
+
+func serverLabel(s dnscheck.ServerState) string {
+	if s.Address == "" {
+		return s.Nameserver
+	}
+	return s.Nameserver + " (" + s.Address + ")"
+}
+
+// parseRecordSpec parses "name type [value]", with name relative to zone.
+func parseRecordSpec(zone, spec string) (dnscheck.Record, error) {
+	fields := strings.Fields(spec)
+	if len(fields) < 2 {
+		return dnscheck.Record{}, fmt.Errorf(`invalid record %q, want "name type [value]"`, spec)
+	}
+
+	typ, err := dnscheck.ParseType(fields[1])
+	if err != nil {
+		return dnscheck.Record{}, fmt.Errorf("invalid record %q: %w", spec, err)
+	}
+
+	return dnscheck.Record{
+		Name:  recordName(zone, fields[0]),
+		Type:  typ,
+		Value: strings.Join(fields[2:], " "),
+	}, nil
+}
+
+// recordName returns the fully qualified name of source in zone. "@", "."
+// and "" are the apex; names ending in a dot are already qualified.
+func recordName(zone, source string) string {
+	switch {
+	case source == "" || source == "@" || source == ".":
+		return zone
+	case strings.HasSuffix(source, "."):
+		return strings.TrimSuffix(source, ".")
+	default:
+		return source + "." + zone
+	}
+}
+
+// recordWait prepares --wait for a command that writes input to zone, or
+// removes it when absent is set. The returned function waits, or does nothing
+// without --wait.
+func recordWait(cmd *cobra.Command, zone string, input infomaniak.RecordInput, absent bool) (func() error, error) {
+	wait, interval, timeout, err := waitFlags(cmd)
+	if err != nil || !wait {
+		return func() error { return nil }, err
+	}
+
+	record, err := recordWatch(zone, input)
+	if err != nil {
+		return nil, err
+	}
+	record.Absent = absent
+
+	checker, err := nameserverChecker(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		watch := dnscheck.Watch{Zone: zone, Records: []dnscheck.Record{record}}
+		_, err := waitForPropagation(ctx, cmd, checker, watch, interval)
+		return err
+	}, nil
+}
+
+// recordWatch returns the record to wait for after writing input to zone.
+func recordWatch(zone string, input infomaniak.RecordInput) (dnscheck.Record, error) {
+	typ, err := dnscheck.ParseType(input.Type)
+	if err != nil {
+		return dnscheck.Record{}, fmt.Errorf("cannot wait for record: %w", err)
+	}
+
+	value := input.Target
+	switch input.Type {
+	case "MX":
+		value = fmt.Sprintf("%d %s", input.Priority, input.Target)
+	case "SRV":
+		value = fmt.Sprintf("%d %d %d %s", input.Priority, input.Weight, input.Port, input.Target)
+	}
+
+	return dnscheck.Record{Name: recordName(zone, input.Source), Type: typ, Value: value}, nil
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var domainsAuthCodeCmd = &cobra.Command{
+	Use:   "auth-code <domain>",
+	Short: "Print the EPP authorization code for an outbound transfer",
+	Long: `Print the EPP authorization code needed to transfer a domain to another
+registrar. The transfer lock must be removed with "infomaniak domains unlock"
+before the new registrar can complete the transfer.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDomainsAuthCode,
+}
+
+func init() {
+	domainsCmd.AddCommand(domainsAuthCodeCmd)
+}
+
+func runDomainsAuthCode(cmd *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	domain, err := client.ShowDomain(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("get auth code: %w", err)
+	}
+
+	code, err := client.GetAuthCode(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("get auth code: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case jsonOut:
+		return printJSON(map[string]any{
+			"domain":          args[0],
+			"auth_code":       code,
+			"transfer_locked": domain.TransferLocked(),
+		})
+	case simple:
+		fmt.Println(code)
+	default:
+		fmt.Printf("Auth code for %s: %s\n", args[0], code)
+	}
+
+	if domain.TransferLocked() {
+		fmt.Fprintf(os.Stderr, "Note: %s is transfer-locked; run 'infomaniak domains unlock %s' before transferring it out.\n", args[0], args[0])
+	}
+	return nil
+}
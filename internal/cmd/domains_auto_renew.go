@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/internal/options"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsAutoRenewCmd = &cobra.Command{
+	Use:       "auto-renew on|off [domain...]",
+	Short:     "Turn automatic renewal on or off",
+	Long:      "Turn automatic renewal on or off for one or more domains, or for every domain expiring within a period with --all-expiring-within.",
+	Args:      cobra.MinimumNArgs(1),
+	ValidArgs: []string{"on", "off"},
+	RunE:      runDomainsAutoRenew,
+}
+
+func init() {
+	addExpiringSelector(domainsAutoRenewCmd)
+	addBulkFlags(domainsAutoRenewCmd)
+
+	domainsCmd.AddCommand(domainsAutoRenewCmd)
+}
+
+func runDomainsAutoRenew(cmd *cobra.Command, args []string) error {
+	enabled, err := options.ParseState(args[0])
+	if err != nil {
+		return err
+	}
+	state := options.FormatState(enabled)
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+
+	domains, err := selectDomainNames(ctx, cmd, client, args[1:])
+	if err != nil {
+		return fmt.Errorf("set auto-renew: %w", err)
+	}
+
+	if len(domains) == 0 {
+		fmt.Println("No domains selected.")
+		return nil
+	}
+
+	return runDomains(cmd, domains, func(ctx context.Context, domain string) (domainOutcome, error) {
+		err := previewDomain(ctx, client, domain, func(d *infomaniak.Domain) {
+			d.AutoRenew = enabled
+		})
+		if err != nil {
+			return domainOutcome{}, fmt.Errorf("set auto-renew: %w", err)
+		}
+
+		if err := client.SetAutoRenew(ctx, domain, enabled); err != nil {
+			return domainOutcome{}, fmt.Errorf("set auto-renew: %w", err)
+		}
+
+		return domainOutcome{
+			data: map[string]any{
+				"domain":     domain,
+				"auto_renew": enabled,
+			},
+			message: fmt.Sprintf("Auto-renew %s for %s.", state, domain),
+		}, nil
+	})
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsCheckCmd = &cobra.Command{
+	Use:   "check <name>...",
+	Short: "Check whether domain names are available for registration",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runDomainsCheck,
+}
+
+func init() {
+	domainsCmd.AddCommand(domainsCheckCmd)
+}
+
+func runDomainsCheck(cmd *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+
+	results := make([]infomaniak.Availability, 0, len(args))
+	for _, name := range args {
+		a, err := client.CheckAvailability(ctx, name)
+		if err != nil {
+			return fmt.Errorf("check domain: %w", err)
+		}
+		results = append(results, *a)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case jsonOut:
+		return printJSON(results)
+	case simple:
+		for _, a := range results {
+			if a.Available {
+				fmt.Println(a.Name)
+			}
+		}
+		return nil
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tAVAILABLE\tPREMIUM\tPRICE/YEAR")
+		for _, a := range results {
+			fmt.Fprintf(w, "%s\t%v\t%v\t%s\n", a.Name, a.Available, a.IsPremium, formatPrice(a.Price, 1))
+		}
+		return w.Flush()
+	}
+}
+
+// formatPrice renders a yearly price multiplied by years, or "-" when the
+// API did not return one.
+func formatPrice(p *infomaniak.Price, years int) string {
+	if p == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s %.2f", p.Currency, p.Amount*float64(years))
+}
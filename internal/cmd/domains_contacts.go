@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsContactsCmd = &cobra.Command{
+	Use:   "contacts",
+	Short: "Manage domain contacts",
+}
+
+func init() {
+	domainsCmd.AddCommand(domainsContactsCmd)
+}
+
+// contactFlags maps contact flags to the ContactInput fields they set.
+func contactFlags(input *infomaniak.ContactInput) map[string]**string {
+	return map[string]**string{
+		"type":         &input.Type,
+		"first-name":   &input.FirstName,
+		"last-name":    &input.LastName,
+		"organization": &input.Organization,
+		"street":       &input.Street,
+		"city":         &input.City,
+		"zip":          &input.Zip,
+		"country":      &input.Country,
+		"phone":        &input.Phone,
+		"fax":          &input.Fax,
+		"email":        &input.Email,
+	}
+}
+
+// addContactFlags registers the flags shared by commands that write a contact.
+func addContactFlags(cmd *cobra.Command) {
+	cmd.Flags().String("type", "", "contact type (individual or company)")
+	cmd.Flags().String("first-name", "", "first name")
+	cmd.Flags().String("last-name", "", "last name")
+	cmd.Flags().String("organization", "", "organization, for company contacts")
+	cmd.Flags().String("street", "", "street address")
+	cmd.Flags().String("city", "", "city")
+	cmd.Flags().String("zip", "", "postal code")
+	cmd.Flags().String("country", "", "ISO 3166 country code (e.g. CH)")
+	cmd.Flags().String("phone", "", "phone number in +CC.NUMBER format")
+	cmd.Flags().String("fax", "", "fax number in +CC.NUMBER format")
+	cmd.Flags().String("email", "", "email address")
+}
+
+// applyContactFlags overlays the contact flags that were set on cmd onto
+// input. A flag set to an empty value clears the field.
+func applyContactFlags(cmd *cobra.Command, input *infomaniak.ContactInput) error {
+	for name, dst := range contactFlags(input) {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+		v, err := cmd.Flags().GetString(name)
+		if err != nil {
+			return fmt.Errorf("parse %s flag: %w", name, err)
+		}
+		*dst = &v
+	}
+
+	if input.Country != nil {
+		country := strings.ToUpper(*input.Country)
+		input.Country = &country
+	}
+	return nil
+}
+
+// contactInputFrom converts an existing contact into an input for updates.
+func contactInputFrom(c infomaniak.Contact) infomaniak.ContactInput {
+	return infomaniak.ContactInput{
+		Type:         optional(c.Type),
+		FirstName:    optional(c.FirstName),
+		LastName:     optional(c.LastName),
+		Organization: optional(c.Organization),
+		Street:       optional(c.Street),
+		City:         optional(c.City),
+		Zip:          optional(c.Zip),
+		Country:      optional(c.Country),
+		Phone:        optional(c.Phone),
+		Fax:          optional(c.Fax),
+		Email:        optional(c.Email),
+	}
+}
+
+// optional returns a pointer to s, or nil for an empty s so that a field the
+// contact does not have is left out of the request.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// contactName returns the display name of a contact.
+func contactName(c infomaniak.Contact) string {
+	if c.Organization != "" {
+		return c.Organization
+	}
+	name := strings.TrimSpace(c.FirstName + " " + c.LastName)
+	if name == "" {
+		return "-"
+	}
+	return name
+}
+
+// contactValidation describes the validation state of a contact.
+func contactValidation(c infomaniak.Contact) string {
+	switch {
+	case !c.IsValidated:
+		return "unvalidated"
+	case c.ValidatedAt != nil:
+		return "validated " + time.Unix(*c.ValidatedAt, 0).Format("2006-01-02")
+	default:
+		return "validated"
+	}
+}
+
+// addContactRoleFlags registers the flags of commands that assign contacts
+// to a new domain.
+func addContactRoleFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("contact", 0, "contact ID to use for all roles")
+	cmd.Flags().Int("owner", 0, "contact ID of the owner (registrant)")
+	cmd.Flags().Int("admin", 0, "contact ID of the administrative contact")
+	cmd.Flags().Int("tech", 0, "contact ID of the technical contact")
+	cmd.Flags().Int("billing", 0, "contact ID of the billing contact")
+}
+
+// contactRoles resolves the role flags registered by addContactRoleFlags.
+// --contact fills every role that has no flag of its own, and every role
+// must end up with a contact.
+func contactRoles(cmd *cobra.Command) (infomaniak.SetContactsInput, error) {
+	contact, err := cmd.Flags().GetInt("contact")
+	if err != nil {
+		return infomaniak.SetContactsInput{}, fmt.Errorf("parse contact flag: %w", err)
+	}
+
+	input := infomaniak.SetContactsInput{Owner: contact, Admin: contact, Tech: contact, Billing: contact}
+
+	roles := []struct {
+		name string
+		dst  *int
+	}{
+		{"owner", &input.Owner},
+		{"admin", &input.Admin},
+		{"tech", &input.Tech},
+		{"billing", &input.Billing},
+	}
+	for _, r := range roles {
+		if cmd.Flags().Changed(r.name) {
+			if *r.dst, err = cmd.Flags().GetInt(r.name); err != nil {
+				return input, fmt.Errorf("parse %s flag: %w", r.name, err)
+			}
+		}
+		if *r.dst <= 0 {
+			return input, fmt.Errorf("no %s contact: set --contact or --%s", r.name, r.name)
+		}
+	}
+
+	return input, nil
+}
+
+func parseContactID(s string) (int, error) {
+	id, err := parseRecordID(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid contact id %q", s)
+	}
+	return id, nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsContactsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a domain contact",
+	Args:  cobra.NoArgs,
+	RunE:  runDomainsContactsCreate,
+}
+
+func init() {
+	addContactFlags(domainsContactsCreateCmd)
+	_ = domainsContactsCreateCmd.MarkFlagRequired("type")
+	_ = domainsContactsCreateCmd.MarkFlagRequired("email")
+
+	domainsContactsCmd.AddCommand(domainsContactsCreateCmd)
+}
+
+func runDomainsContactsCreate(cmd *cobra.Command, _ []string) error {
+	var input infomaniak.ContactInput
+	if err := applyContactFlags(cmd, &input); err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	contact, err := client.CreateContact(ctx, input)
+	if err != nil {
+		return fmt.Errorf("create contact: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+
+	if jsonOut {
+		return printJSON(contact)
+	}
+
+	fmt.Printf("Contact %d created.\n", contact.ID)
+	return nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var domainsContactsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the domain contacts of the account",
+	Args:  cobra.NoArgs,
+	RunE:  runDomainsContactsList,
+}
+
+func init() {
+	domainsContactsListCmd.Flags().Bool("unvalidated", false, "only show contacts that are not validated yet")
+
+	domainsContactsCmd.AddCommand(domainsContactsListCmd)
+}
+
+func runDomainsContactsList(cmd *cobra.Command, _ []string) error {
+	unvalidated, err := cmd.Flags().GetBool("unvalidated")
+	if err != nil {
+		return fmt.Errorf("parse unvalidated flag: %w", err)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+
+	contacts, err := client.ListContacts(ctx)
+	if err != nil {
+		return fmt.Errorf("list contacts: %w", err)
+	}
+
+	if unvalidated {
+		filtered := contacts[:0]
+		for _, c := range contacts {
+			if !c.IsValidated {
+				filtered = append(filtered, c)
+			}
+		}
+		contacts = filtered
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case jsonOut:
+		return printJSON(contacts)
+	case simple:
+		for _, c := range contacts {
+			fmt.Printf("%d %s\n", c.ID, c.Email)
+		}
+		return nil
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTYPE\tNAME\tEMAIL\tSTATUS")
+		for _, c := range contacts {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", c.ID, orDash(c.Type), contactName(c), c.Email, contactValidation(c))
+		}
+		return w.Flush()
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsContactsSetCmd = &cobra.Command{
+	Use:   "set <domain>...",
+	Short: "Assign existing contacts to domains",
+	Long:  "Assign existing contacts to the roles of a domain. Roles whose flag is not given keep their current contact.",
+	Args:  cobra.ArbitraryArgs,
+	RunE:  runDomainsContactsSet,
+}
+
+func init() {
+	domainsContactsSetCmd.Flags().Int("owner", 0, "contact ID of the owner (registrant)")
+	domainsContactsSetCmd.Flags().Int("admin", 0, "contact ID of the administrative contact")
+	domainsContactsSetCmd.Flags().Int("tech", 0, "contact ID of the technical contact")
+	domainsContactsSetCmd.Flags().Int("billing", 0, "contact ID of the billing contact")
+	domainsContactsSetCmd.MarkFlagsOneRequired("owner", "admin", "tech", "billing")
+	addBulkFlags(domainsContactsSetCmd)
+
+	domainsContactsCmd.AddCommand(domainsContactsSetCmd)
+}
+
+func runDomainsContactsSet(cmd *cobra.Command, args []string) error {
+	var input infomaniak.SetContactsInput
+
+	roles := map[string]*int{
+		"owner":   &input.Owner,
+		"admin":   &input.Admin,
+		"tech":    &input.Tech,
+		"billing": &input.Billing,
+	}
+	for name, dst := range roles {
+		v, err := cmd.Flags().GetInt(name)
+		if err != nil {
+			return fmt.Errorf("parse %s flag: %w", name, err)
+		}
+		if cmd.Flags().Changed(name) && v <= 0 {
+			return fmt.Errorf("invalid %s contact id %d", name, v)
+		}
+		*dst = v
+	}
+
+	domains, err := domainArgs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	// The dry-run preview shows the new contacts in full.
+	contacts := map[int]*infomaniak.Contact{}
+	if dryRun() {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+		defer cancel()
+
+		for _, id := range []int{input.Owner, input.Admin, input.Tech, input.Billing} {
+			if id == 0 || contacts[id] != nil {
+				continue
+			}
+			c, err := client.ShowContact(ctx, id)
+			if err != nil {
+				return fmt.Errorf("set contacts: %w", err)
+			}
+			contacts[id] = c
+		}
+	}
+
+	return runDomains(cmd, domains, func(ctx context.Context, domain string) (domainOutcome, error) {
+		err := previewDomain(ctx, client, domain, func(d *infomaniak.Domain) {
+			reassignContact(&d.Contacts.Owner, contacts[input.Owner])
+			reassignContact(&d.Contacts.Admin, contacts[input.Admin])
+			reassignContact(&d.Contacts.Tech, contacts[input.Tech])
+			reassignContact(&d.Contacts.Billing, contacts[input.Billing])
+		})
+		if err != nil {
+			return domainOutcome{}, fmt.Errorf("set contacts: %w", err)
+		}
+
+		if err := client.SetDomainContacts(ctx, domain, input); err != nil {
+			return domainOutcome{}, fmt.Errorf("set contacts: %w", err)
+		}
+
+		return domainOutcome{
+			data: map[string]any{
+				"domain":   domain,
+				"contacts": input,
+				"status":   "updated",
+			},
+			message: fmt.Sprintf("Contacts for %s updated successfully.", domain),
+		}, nil
+	})
+}
+
+// reassignContact points a role at another contact for a dry-run preview.
+// A nil contact leaves the role unchanged.
+func reassignContact(role **infomaniak.Contact, c *infomaniak.Contact) {
+	if c != nil {
+		*role = c
+	}
+}
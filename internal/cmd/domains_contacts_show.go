@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsContactsShowCmd = &cobra.Command{
+	Use:   "show <domain>",
+	Short: "Show the owner, admin, tech and billing contacts of a domain",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDomainsContactsShow,
+}
+
+func init() {
+	domainsContactsCmd.AddCommand(domainsContactsShowCmd)
+}
+
+func runDomainsContactsShow(cmd *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	domain, err := client.ShowDomain(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("show contacts: %w", err)
+	}
+
+	roles := []struct {
+		name    string
+		contact *infomaniak.Contact
+	}{
+		{"owner", domain.Contacts.Owner},
+		{"admin", domain.Contacts.Admin},
+		{"tech", domain.Contacts.Tech},
+		{"billing", domain.Contacts.Billing},
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case jsonOut:
+		return printJSON(domain.Contacts)
+	case simple:
+		for _, r := range roles {
+			if r.contact == nil {
+				continue
+			}
+			fmt.Printf("%s %d %s\n", r.name, r.contact.ID, contactValidation(*r.contact))
+		}
+		return nil
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ROLE\tID\tNAME\tEMAIL\tSTATUS")
+		for _, r := range roles {
+			if r.contact == nil {
+				fmt.Fprintf(w, "%s\t-\t-\t-\t-\n", r.name)
+				continue
+			}
+			c := *r.contact
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", r.name, c.ID, contactName(c), c.Email, contactValidation(c))
+		}
+		return w.Flush()
+	}
+}
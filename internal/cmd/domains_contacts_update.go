@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var domainsContactsUpdateCmd = &cobra.Command{
+	Use:   "update <contact-id>",
+	Short: "Update a domain contact",
+	Long:  "Update a domain contact. Only the flags that are given are changed; all other fields keep their current value. An empty value, e.g. --fax \"\", clears the field.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDomainsContactsUpdate,
+}
+
+func init() {
+	addContactFlags(domainsContactsUpdateCmd)
+
+	domainsContactsCmd.AddCommand(domainsContactsUpdateCmd)
+}
+
+func runDomainsContactsUpdate(cmd *cobra.Command, args []string) error {
+	id, err := parseContactID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	current, err := client.ShowContact(ctx, id)
+	if err != nil {
+		return fmt.Errorf("update contact: %w", err)
+	}
+
+	input := contactInputFrom(*current)
+	if err := applyContactFlags(cmd, &input); err != nil {
+		return err
+	}
+
+	contact, err := client.UpdateContact(ctx, id, input)
+	if err != nil {
+		return fmt.Errorf("update contact: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+
+	if jsonOut {
+		return printJSON(contact)
+	}
+
+	fmt.Printf("Contact %d updated successfully.\n", id)
+	return nil
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var domainsDNSSECCmd = &cobra.Command{
+	Use:   "dnssec",
+	Short: "Manage DNSSEC for a domain",
+}
+
+func init() {
+	domainsCmd.AddCommand(domainsDNSSECCmd)
+}
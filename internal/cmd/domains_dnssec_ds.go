@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var domainsDNSSECDSCmd = &cobra.Command{
+	Use:   "ds",
+	Short: "Manage registry DS records for domains on external nameservers",
+}
+
+func init() {
+	domainsDNSSECCmd.AddCommand(domainsDNSSECDSCmd)
+}
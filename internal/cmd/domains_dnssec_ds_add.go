@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/internal/zone"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsDNSSECDSAddCmd = &cobra.Command{
+	Use:   "add <domain> [ds-record]",
+	Short: "Publish a DS record for a domain",
+	Long: `Publish a DS record at the registry for a domain served by external
+nameservers. The record can be given in presentation format, as printed by
+dnssec-dsfromkey, or field by field with flags:
+
+  infomaniak domains dnssec ds add example.ch "12345 13 2 3A1F..."
+  infomaniak domains dnssec ds add example.ch --key-tag 12345 --algorithm 13 --digest-type 2 --digest 3A1F...`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runDomainsDNSSECDSAdd,
+}
+
+func init() {
+	domainsDNSSECDSAddCmd.Flags().Int("key-tag", 0, "key tag of the DNSKEY")
+	domainsDNSSECDSAddCmd.Flags().Int("algorithm", 0, "DNSKEY algorithm number (e.g. 13 for ECDSAP256SHA256)")
+	domainsDNSSECDSAddCmd.Flags().Int("digest-type", 2, "digest type (1 SHA-1, 2 SHA-256, 4 SHA-384)")
+	domainsDNSSECDSAddCmd.Flags().String("digest", "", "hex-encoded digest")
+	domainsDNSSECDSAddCmd.MarkFlagsRequiredTogether("key-tag", "algorithm", "digest")
+
+	domainsDNSSECDSCmd.AddCommand(domainsDNSSECDSAddCmd)
+}
+
+func runDomainsDNSSECDSAdd(cmd *cobra.Command, args []string) error {
+	input, err := dsInputFrom(cmd, args[1:])
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	record, err := client.AddDSRecord(ctx, args[0], input)
+	if err != nil {
+		return fmt.Errorf("add ds record: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+
+	if jsonOut {
+		return printJSON(record)
+	}
+
+	fmt.Printf("DS record %d (key tag %d) added to %s.\n", record.ID, record.KeyTag, args[0])
+	return nil
+}
+
+// dsInputFrom builds the DS record either from the positional argument or
+// from the field flags, validating the digest in both cases.
+func dsInputFrom(cmd *cobra.Command, args []string) (infomaniak.DSRecordInput, error) {
+	if len(args) == 1 {
+		if cmd.Flags().Changed("digest") {
+			return infomaniak.DSRecordInput{}, fmt.Errorf("give the DS record either as an argument or with --digest, not both")
+		}
+		return zone.ParseDS(args[0])
+	}
+
+	if !cmd.Flags().Changed("digest") {
+		return infomaniak.DSRecordInput{}, fmt.Errorf("a DS record argument or --key-tag, --algorithm and --digest are required")
+	}
+
+	var input infomaniak.DSRecordInput
+	var err error
+	if input.KeyTag, err = cmd.Flags().GetInt("key-tag"); err != nil {
+		return input, fmt.Errorf("parse key-tag flag: %w", err)
+	}
+	if input.Algorithm, err = cmd.Flags().GetInt("algorithm"); err != nil {
+		return input, fmt.Errorf("parse algorithm flag: %w", err)
+	}
+	if input.DigestType, err = cmd.Flags().GetInt("digest-type"); err != nil {
+		return input, fmt.Errorf("parse digest-type flag: %w", err)
+	}
+	if input.Digest, err = cmd.Flags().GetString("digest"); err != nil {
+		return input, fmt.Errorf("parse digest flag: %w", err)
+	}
+	input.Digest = strings.ToUpper(input.Digest)
+
+	if err := zone.ValidateDS(input); err != nil {
+		return input, err
+	}
+
+	return input, nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var domainsDNSSECDSListCmd = &cobra.Command{
+	Use:   "list <domain>",
+	Short: "List DS records published for a domain",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDomainsDNSSECDSList,
+}
+
+func init() {
+	domainsDNSSECDSCmd.AddCommand(domainsDNSSECDSListCmd)
+}
+
+func runDomainsDNSSECDSList(cmd *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	records, err := client.ListDSRecords(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("list ds records: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case jsonOut:
+		return printJSON(records)
+	case simple:
+		for _, r := range records {
+			fmt.Printf("%d %d %d %s\n", r.KeyTag, r.Algorithm, r.DigestType, r.Digest)
+		}
+		return nil
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tKEY TAG\tALGORITHM\tDIGEST TYPE\tDIGEST")
+		for _, r := range records {
+			fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%s\n", r.ID, r.KeyTag, r.Algorithm, r.DigestType, r.Digest)
+		}
+		return w.Flush()
+	}
+}
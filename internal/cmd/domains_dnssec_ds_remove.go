@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var domainsDNSSECDSRemoveCmd = &cobra.Command{
+	Use:   "remove <domain> <ds-id>",
+	Short: "Withdraw a DS record from the registry",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runDomainsDNSSECDSRemove,
+}
+
+func init() {
+	domainsDNSSECDSCmd.AddCommand(domainsDNSSECDSRemoveCmd)
+}
+
+func runDomainsDNSSECDSRemove(cmd *cobra.Command, args []string) error {
+	id, err := parseRecordID(args[1])
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	if err := client.RemoveDSRecord(ctx, args[0], id); err != nil {
+		return fmt.Errorf("remove ds record: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+
+	if jsonOut {
+		return printJSON(map[string]any{
+			"domain": args[0],
+			"id":     id,
+			"status": "removed",
+		})
+	}
+
+	fmt.Printf("DS record %d removed from %s.\n", id, args[0])
+	return nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsDNSSECEnableCmd = &cobra.Command{
+	Use:   "enable <domain>...",
+	Short: "Enable DNSSEC for domains on Infomaniak nameservers",
+	Args:  cobra.ArbitraryArgs,
+	RunE:  runDomainsDNSSECToggle(true),
+}
+
+var domainsDNSSECDisableCmd = &cobra.Command{
+	Use:   "disable <domain>...",
+	Short: "Disable DNSSEC for domains",
+	Args:  cobra.ArbitraryArgs,
+	RunE:  runDomainsDNSSECToggle(false),
+}
+
+func init() {
+	addBulkFlags(domainsDNSSECEnableCmd)
+	addBulkFlags(domainsDNSSECDisableCmd)
+
+	domainsDNSSECCmd.AddCommand(domainsDNSSECEnableCmd)
+	domainsDNSSECCmd.AddCommand(domainsDNSSECDisableCmd)
+}
+
+func runDomainsDNSSECToggle(enable bool) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		domains, err := domainArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		status := "disabled"
+		if enable {
+			status = "enabled"
+		}
+
+		return runDomains(cmd, domains, func(ctx context.Context, domain string) (domainOutcome, error) {
+			err := previewDomain(ctx, client, domain, func(d *infomaniak.Domain) {
+				d.Options.DNSSEC = enable
+			})
+			if err != nil {
+				return domainOutcome{}, fmt.Errorf("update dnssec: %w", err)
+			}
+
+			if enable {
+				err = client.EnableDNSSEC(ctx, domain)
+			} else {
+				err = client.DisableDNSSEC(ctx, domain)
+			}
+			if err != nil {
+				return domainOutcome{}, fmt.Errorf("update dnssec: %w", err)
+			}
+
+			return domainOutcome{
+				data: map[string]string{
+					"domain": domain,
+					"status": status,
+				},
+				message: fmt.Sprintf("DNSSEC %s for %s.", status, domain),
+			}, nil
+		})
+	}
+}
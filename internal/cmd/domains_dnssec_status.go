@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var domainsDNSSECStatusCmd = &cobra.Command{
+	Use:   "status <domain>",
+	Short: "Show the DNSSEC state of a domain",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDomainsDNSSECStatus,
+}
+
+func init() {
+	domainsDNSSECCmd.AddCommand(domainsDNSSECStatusCmd)
+}
+
+func runDomainsDNSSECStatus(cmd *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	state, err := client.ShowDNSSEC(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("show dnssec: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case jsonOut:
+		return printJSON(state)
+	case simple:
+		fmt.Println(state.Enabled)
+		return nil
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Domain:\t%s\n", args[0])
+		fmt.Fprintf(w, "DNSSEC:\t%v\n", state.Enabled)
+		fmt.Fprintf(w, "DS records:\t%d\n", len(state.DSRecords))
+		return w.Flush()
+	}
+}
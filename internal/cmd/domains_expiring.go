@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/internal/expiry"
+)
+
+var domainsExpiringCmd = &cobra.Command{
+	Use:   "expiring",
+	Short: "Report domains that expire soon, usable as a monitoring check",
+	Long: `Report domains that expire soon, usable as a monitoring check.
+
+Domains expiring within --within are WARNING, within --critical or already
+expired are CRITICAL. Domains with a renewal warranty are listed but do not
+raise the status until they have expired. Domains without an expiry date are
+UNKNOWN.
+
+The exit status follows the Nagios plugin convention: 0 OK, 1 WARNING,
+2 CRITICAL, 3 UNKNOWN. Errors, such as the API being unreachable, are
+UNKNOWN as well. With --nagios, the output is a single status line with
+perfdata followed by one line per domain.`,
+	Args: cobra.NoArgs,
+	RunE: runDomainsExpiring,
+	// The exit status is the check result; usage output would only add noise.
+	SilenceUsage: true,
+}
+
+func init() {
+	domainsExpiringCmd.Flags().String("within", "60d", "warn about domains expiring within this period")
+	domainsExpiringCmd.Flags().String("critical", "14d", "critical for domains expiring within this period")
+	domainsExpiringCmd.Flags().Bool("nagios", false, "print Nagios plugin output")
+
+	domainsCmd.AddCommand(domainsExpiringCmd)
+}
+
+func runDomainsExpiring(cmd *cobra.Command, _ []string) error {
+	nagios, err := cmd.Flags().GetBool("nagios")
+	if err != nil {
+		return &ExitError{Code: int(expiry.Unknown), Err: fmt.Errorf("parse nagios flag: %w", err)}
+	}
+
+	report, th, err := checkExpiring(cmd)
+	if err != nil {
+		return unknownResult(cmd, err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	switch {
+	case nagios:
+		fmt.Println(report.Summary(th))
+		for _, e := range report.Entries {
+			if e.Status == expiry.Unknown {
+				fmt.Printf("%s: %s has no expiry date\n", e.Status, e.Name)
+				continue
+			}
+			fmt.Printf("%s: %s expires %s (%d days)%s\n",
+				e.Status, e.Name, e.ExpiresAt.Format("2006-01-02"), e.DaysLeft, coveredNote(e))
+		}
+	case jsonOut:
+		if err := printJSON(report); err != nil {
+			return &ExitError{Code: int(expiry.Unknown), Err: err}
+		}
+	case simple:
+		for _, e := range report.Entries {
+			fmt.Println(e.Name)
+		}
+	default:
+		printExpiryGroups(report, th)
+	}
+
+	if report.Status != expiry.OK {
+		return &ExitError{Code: int(report.Status)}
+	}
+	return nil
+}
+
+// unknownResult reports err as the UNKNOWN check result: as the status line
+// with --nagios, and as the error otherwise. Failures must not exit with a
+// code a monitoring system reads as a check result, so they are all UNKNOWN.
+func unknownResult(cmd *cobra.Command, err error) error {
+	if nagios, _ := cmd.Flags().GetBool("nagios"); nagios {
+		fmt.Printf("DOMAINS %s - %v\n", expiry.Unknown, err)
+		return &ExitError{Code: int(expiry.Unknown)}
+	}
+	return &ExitError{Code: int(expiry.Unknown), Err: err}
+}
+
+func checkExpiring(cmd *cobra.Command) (expiry.Report, expiry.Thresholds, error) {
+	var th expiry.Thresholds
+
+	within, err := cmd.Flags().GetString("within")
+	if err != nil {
+		return expiry.Report{}, th, fmt.Errorf("parse within flag: %w", err)
+	}
+	if th.Warning, err = parseDuration(within); err != nil {
+		return expiry.Report{}, th, fmt.Errorf("parse within flag: %w", err)
+	}
+
+	critical, err := cmd.Flags().GetString("critical")
+	if err != nil {
+		return expiry.Report{}, th, fmt.Errorf("parse critical flag: %w", err)
+	}
+	if th.Critical, err = parseDuration(critical); err != nil {
+		return expiry.Report{}, th, fmt.Errorf("parse critical flag: %w", err)
+	}
+	if th.Critical > th.Warning {
+		return expiry.Report{}, th, fmt.Errorf("--critical (%s) must not be longer than --within (%s)", critical, within)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return expiry.Report{}, th, err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+
+	domains, err := client.ListDomains(ctx)
+	if err != nil {
+		return expiry.Report{}, th, fmt.Errorf("list domains: %w", err)
+	}
+
+	return expiry.Check(domains, time.Now(), th), th, nil
+}
+
+func printExpiryGroups(report expiry.Report, th expiry.Thresholds) {
+	if len(report.Entries) == 0 {
+		fmt.Printf("No domain expires within %s.\n", expiry.FormatDays(th.Warning))
+		return
+	}
+
+	groups := []struct {
+		status expiry.Status
+		title  string
+	}{
+		{expiry.Critical, fmt.Sprintf("CRITICAL (expired or within %s)", expiry.FormatDays(th.Critical))},
+		{expiry.Warning, fmt.Sprintf("WARNING (within %s)", expiry.FormatDays(th.Warning))},
+		{expiry.Unknown, "UNKNOWN (no expiry date)"},
+		{expiry.OK, "COVERED (renewal warranty)"},
+	}
+
+	first := true
+	for _, g := range groups {
+		if report.Count(g.status) == 0 {
+			continue
+		}
+		if !first {
+			fmt.Println()
+		}
+		first = false
+
+		fmt.Println(g.title)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "  NAME\tEXPIRES\tDAYS LEFT")
+		for _, e := range report.Entries {
+			switch {
+			case e.Status != g.status:
+			case e.ExpiresAt.IsZero():
+				fmt.Fprintf(w, "  %s\t-\t-\n", e.Name)
+			default:
+				fmt.Fprintf(w, "  %s\t%s\t%d\n", e.Name, e.ExpiresAt.Format("2006-01-02"), e.DaysLeft)
+			}
+		}
+		_ = w.Flush()
+	}
+}
+
+func coveredNote(e expiry.Entry) string {
+	if e.Covered {
+		return " [renewal warranty]"
+	}
+	return ""
+}
@@ -2,15 +2,13 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"github.com/yannick/infomaniak/internal/api"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
 )
 
 var domainsListCmd = &cobra.Command{
@@ -20,23 +18,40 @@ var domainsListCmd = &cobra.Command{
 }
 
 func init() {
+	domainsListCmd.Flags().Int("limit", 0, "maximum number of domains to list (0 for all)")
+	domainsListCmd.Flags().Int("page", 0, "only fetch this page of results (starting at 1)")
+	domainsListCmd.Flags().Int("per-page", infomaniak.DefaultPerPage, "number of domains per page")
+
 	domainsCmd.AddCommand(domainsListCmd)
 }
 
 func runDomainsList(cmd *cobra.Command, _ []string) error {
-	token := viper.GetString("token")
-	if token == "" {
-		return fmt.Errorf("token is required: set via --token, config file, or $INFOMANIAK_TOKEN")
+	var opts infomaniak.ListOptions
+	var err error
+	if opts.Limit, err = cmd.Flags().GetInt("limit"); err != nil {
+		return fmt.Errorf("parse limit flag: %w", err)
+	}
+	if opts.Page, err = cmd.Flags().GetInt("page"); err != nil {
+		return fmt.Errorf("parse page flag: %w", err)
+	}
+	if opts.PerPage, err = cmd.Flags().GetInt("per-page"); err != nil {
+		return fmt.Errorf("parse per-page flag: %w", err)
 	}
 
-	client := api.NewClient(api.ClientConfig{Token: token})
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
 	defer cancel()
 
-	domains, err := client.ListDomains(ctx)
-	if err != nil {
-		return fmt.Errorf("list domains: %w", err)
+	domains := []infomaniak.Domain{}
+	for d, err := range client.IterDomains(ctx, opts) {
+		if err != nil {
+			return fmt.Errorf("list domains: %w", err)
+		}
+		domains = append(domains, d)
 	}
 
 	jsonOut, _ := cmd.Flags().GetBool("json")
@@ -44,9 +59,7 @@ func runDomainsList(cmd *cobra.Command, _ []string) error {
 
 	switch {
 	case jsonOut:
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(domains)
+		return printJSON(domains)
 	case simple:
 		for _, d := range domains {
 			fmt.Println(d.Name)
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsLockCmd = &cobra.Command{
+	Use:   "lock <domain>...",
+	Short: "Set the registrar transfer lock of domains",
+	Args:  cobra.ArbitraryArgs,
+	RunE:  runDomainsTransferLock(true),
+}
+
+var domainsUnlockCmd = &cobra.Command{
+	Use:   "unlock <domain>...",
+	Short: "Remove the registrar transfer lock of domains",
+	Args:  cobra.ArbitraryArgs,
+	RunE:  runDomainsTransferLock(false),
+}
+
+func init() {
+	addBulkFlags(domainsLockCmd)
+	addBulkFlags(domainsUnlockCmd)
+
+	domainsCmd.AddCommand(domainsLockCmd)
+	domainsCmd.AddCommand(domainsUnlockCmd)
+}
+
+func runDomainsTransferLock(lock bool) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		domains, err := domainArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		state := "unlocked"
+		if lock {
+			state = "locked"
+		}
+
+		return runDomains(cmd, domains, func(ctx context.Context, name string) (domainOutcome, error) {
+			domain, err := client.ShowDomain(ctx, name)
+			if err != nil {
+				return domainOutcome{}, fmt.Errorf("set transfer lock: %w", err)
+			}
+
+			status := "updated"
+			message := fmt.Sprintf("%s is now %s.", name, state)
+			if domain.TransferLocked() == lock {
+				status = "unchanged"
+				message = fmt.Sprintf("%s is already %s.", name, state)
+			} else {
+				err := previewDomain(ctx, client, name, func(d *infomaniak.Domain) {
+					d.Status = slices.DeleteFunc(d.Status, func(s string) bool {
+						return s == infomaniak.StatusClientTransferProhibited
+					})
+					if lock {
+						d.Status = append(d.Status, infomaniak.StatusClientTransferProhibited)
+					}
+				})
+				if err != nil {
+					return domainOutcome{}, fmt.Errorf("set transfer lock: %w", err)
+				}
+				if err := client.SetTransferLock(ctx, name, lock); err != nil {
+					return domainOutcome{}, fmt.Errorf("set transfer lock: %w", err)
+				}
+			}
+
+			return domainOutcome{
+				data: map[string]any{
+					"domain": name,
+					"locked": lock,
+					"status": status,
+				},
+				message: message,
+			}, nil
+		})
+	}
+}
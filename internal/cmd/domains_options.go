@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var domainsOptionsCmd = &cobra.Command{
+	Use:   "options",
+	Short: "Manage WHOIS privacy, DNS anycast and renewal warranty",
+}
+
+func init() {
+	domainsCmd.AddCommand(domainsOptionsCmd)
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/internal/options"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsOptionsSetCmd = &cobra.Command{
+	Use:   "set [domain...]",
+	Short: "Turn domain options on or off",
+	Long: `Turn domain options on or off for the named domains, or apply them as a
+policy to every domain of the account with --all or to the domains matching
+a pattern with --match:
+
+  infomaniak domains options set example.ch --privacy=on --anycast=off
+  infomaniak domains options set --match '*.com' --privacy=on
+
+In policy mode, the domains that do not comply are previewed and only
+changed after typing "yes", or with --auto-approve. Domains that already
+comply are never touched. With --json a policy needs --auto-approve; use
+--dry-run --json to get the changes as JSON without applying them.`,
+	RunE: runDomainsOptionsSet,
+}
+
+func init() {
+	domainsOptionsSetCmd.Flags().String("privacy", "", "WHOIS privacy (on or off)")
+	domainsOptionsSetCmd.Flags().String("anycast", "", "DNS anycast (on or off)")
+	domainsOptionsSetCmd.Flags().String("renewal-warranty", "", "renewal warranty (on or off)")
+	domainsOptionsSetCmd.Flags().Bool("all", false, "apply to every domain of the account")
+	domainsOptionsSetCmd.Flags().String("match", "", "apply to domains matching this glob pattern (e.g. '*.com')")
+	domainsOptionsSetCmd.Flags().Bool("auto-approve", false, "apply a policy without asking for confirmation")
+	addBulkFlags(domainsOptionsSetCmd)
+
+	domainsOptionsCmd.AddCommand(domainsOptionsSetCmd)
+}
+
+func runDomainsOptionsSet(cmd *cobra.Command, args []string) error {
+	var policy options.Policy
+
+	states := []struct {
+		name string
+		dst  **bool
+	}{
+		{"privacy", &policy.Privacy},
+		{"anycast", &policy.Anycast},
+		{"renewal-warranty", &policy.RenewalWarranty},
+	}
+	for _, s := range states {
+		v, err := cmd.Flags().GetString(s.name)
+		if err != nil {
+			return fmt.Errorf("parse %s flag: %w", s.name, err)
+		}
+		if v == "" {
+			continue
+		}
+		state, err := options.ParseState(v)
+		if err != nil {
+			return fmt.Errorf("parse %s flag: %w", s.name, err)
+		}
+		*s.dst = &state
+	}
+	if policy.Empty() {
+		return fmt.Errorf("set at least one of --privacy, --anycast or --renewal-warranty")
+	}
+
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return fmt.Errorf("parse all flag: %w", err)
+	}
+
+	match, err := cmd.Flags().GetString("match")
+	if err != nil {
+		return fmt.Errorf("parse match flag: %w", err)
+	}
+	if _, err := path.Match(match, ""); err != nil {
+		return fmt.Errorf("parse match flag: invalid pattern %q", match)
+	}
+
+	policyMode := all || match != ""
+	if policyMode && (len(args) > 0 || cmd.Flags().Changed("from-file")) {
+		return fmt.Errorf("--all and --match cannot be combined with domain names")
+	}
+
+	autoApprove, err := cmd.Flags().GetBool("auto-approve")
+	if err != nil {
+		return fmt.Errorf("parse auto-approve flag: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	if policyMode && jsonOut && !autoApprove && !dryRun() {
+		return fmt.Errorf("--json leaves no prompt to confirm the policy with: use --auto-approve, or --dry-run to preview the changes")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Minute)
+	defer cancel()
+
+	var domains []infomaniak.Domain
+	if policyMode {
+		list, err := client.ListDomains(ctx)
+		if err != nil {
+			return fmt.Errorf("set options: %w", err)
+		}
+		for _, d := range list {
+			if ok, _ := path.Match(match, d.Name); match == "" || ok {
+				domains = append(domains, d)
+			}
+		}
+	} else {
+		names, err := domainArgs(cmd, args)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			d, err := client.ShowDomain(ctx, name)
+			if err != nil {
+				return fmt.Errorf("set options: %w", err)
+			}
+			domains = append(domains, *d)
+		}
+	}
+
+	diffs := options.Diff(domains, policy)
+
+	if len(diffs) == 0 {
+		if jsonOut {
+			return printJSON([]options.DomainChanges{})
+		}
+		fmt.Printf("No changes. All %d domain(s) already match.\n", len(domains))
+		return nil
+	}
+
+	if policyMode && !autoApprove {
+		// Only a dry run gets here with --json.
+		if jsonOut {
+			return printJSON(diffs)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DOMAIN\tOPTION\tCURRENT\tNEW")
+		for _, d := range diffs {
+			for _, c := range d.Changes {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", d.Domain, c.Option, options.FormatState(c.From), options.FormatState(c.To))
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		// Policy mode takes no domain list, so stdin is free for the answer.
+		ok, err := confirm(fmt.Sprintf("\nChange options of %d of %d domain(s)?", len(diffs), len(domains)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("options update cancelled")
+		}
+	}
+
+	changes := make(map[string]options.DomainChanges, len(diffs))
+	names := make([]string, len(diffs))
+	for i, d := range diffs {
+		changes[d.Domain] = d
+		names[i] = d.Domain
+	}
+
+	return runDomains(cmd, names, func(ctx context.Context, domain string) (domainOutcome, error) {
+		d := changes[domain]
+		err := previewDomain(ctx, client, domain, func(dom *infomaniak.Domain) {
+			input := d.Input()
+			if input.DomainPrivacy != nil {
+				dom.Options.DomainPrivacy = *input.DomainPrivacy
+			}
+			if input.DNSAnycast != nil {
+				dom.Options.DNSAnycast = *input.DNSAnycast
+			}
+			if input.RenewalWarranty != nil {
+				dom.Options.RenewalWarranty = *input.RenewalWarranty
+			}
+		})
+		if err != nil {
+			return domainOutcome{}, fmt.Errorf("set options: %w", err)
+		}
+
+		if err := client.UpdateOptions(ctx, domain, d.Input()); err != nil {
+			return domainOutcome{}, fmt.Errorf("set options: %w", err)
+		}
+
+		return domainOutcome{
+			data:    d,
+			message: fmt.Sprintf("Updated %s: %s.", domain, describeChanges(d.Changes)),
+		}, nil
+	})
+}
+
+// describeChanges renders changes as "privacy on, anycast off".
+func describeChanges(changes []options.Change) string {
+	parts := make([]string, len(changes))
+	for i, c := range changes {
+		parts[i] = fmt.Sprintf("%s %s", c.Option, options.FormatState(c.To))
+	}
+	return strings.Join(parts, ", ")
+}
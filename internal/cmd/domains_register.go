@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsRegisterCmd = &cobra.Command{
+	Use:   "register <name>",
+	Short: "Register a new domain",
+	Long: `Register a new domain. The availability and price are checked first and the
+registration is only submitted after typing "yes" at the price confirmation,
+or with --auto-approve. Registered domains are charged to the account. When
+the API reports no price, registering requires --auto-approve.
+
+--contact is used for every role unless --owner, --admin, --tech or --billing
+override it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDomainsRegister,
+}
+
+func init() {
+	domainsRegisterCmd.Flags().Int("years", 1, "registration period in years")
+	addContactRoleFlags(domainsRegisterCmd)
+	domainsRegisterCmd.Flags().StringSlice("nameservers", nil, "comma-separated list of nameservers (default Infomaniak)")
+	domainsRegisterCmd.Flags().Bool("auto-approve", false, "register without asking for confirmation")
+
+	domainsCmd.AddCommand(domainsRegisterCmd)
+}
+
+func runDomainsRegister(cmd *cobra.Command, args []string) error {
+	years, err := cmd.Flags().GetInt("years")
+	if err != nil {
+		return fmt.Errorf("parse years flag: %w", err)
+	}
+	if years < 1 || years > 10 {
+		return fmt.Errorf("years must be between 1 and 10, got %d", years)
+	}
+
+	contacts, err := contactRoles(cmd)
+	if err != nil {
+		return err
+	}
+
+	input := infomaniak.RegisterDomainInput{
+		Name:     args[0],
+		Years:    years,
+		Contacts: contacts,
+	}
+
+	if input.Nameservers, err = cmd.Flags().GetStringSlice("nameservers"); err != nil {
+		return fmt.Errorf("parse nameservers flag: %w", err)
+	}
+
+	autoApprove, err := cmd.Flags().GetBool("auto-approve")
+	if err != nil {
+		return fmt.Errorf("parse auto-approve flag: %w", err)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+	defer cancel()
+
+	availability, err := client.CheckAvailability(ctx, input.Name)
+	if err != nil {
+		return fmt.Errorf("register domain: %w", err)
+	}
+	if !availability.Available {
+		return fmt.Errorf("register domain: %s is not available", input.Name)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+
+	if jsonOut && !autoApprove {
+		return printJSON(map[string]any{
+			"name":         input.Name,
+			"years":        years,
+			"is_premium":   availability.IsPremium,
+			"price":        availability.Price,
+			"total_amount": totalAmount(availability.Price, years),
+		})
+	}
+
+	if !autoApprove {
+		// Premium domains can cost far more than the usual rate, so never ask
+		// to approve an unknown amount.
+		if availability.Price == nil {
+			return fmt.Errorf("register domain: the price of %s is unknown; use --auto-approve to register it anyway", input.Name)
+		}
+		premium := ""
+		if availability.IsPremium {
+			premium = " This is a premium domain."
+		}
+		ok, err := confirm(fmt.Sprintf("Register %s for %d year(s) at %s per year, %s in total?%s",
+			input.Name, years, formatPrice(availability.Price, 1), formatPrice(availability.Price, years), premium))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("registration cancelled")
+		}
+	}
+
+	domain, err := client.RegisterDomain(ctx, input)
+	if err != nil {
+		return fmt.Errorf("register domain: %w", err)
+	}
+
+	if jsonOut {
+		return printJSON(domain)
+	}
+
+	fmt.Printf("Domain %s registered", domain.Name)
+	if domain.ExpiresAt > 0 {
+		fmt.Printf(" until %s", time.Unix(domain.ExpiresAt, 0).Format("2006-01-02"))
+	}
+	fmt.Println(".")
+	return nil
+}
+
+// totalAmount returns the price for years, or nil when the price is unknown.
+func totalAmount(p *infomaniak.Price, years int) *float64 {
+	if p == nil {
+		return nil
+	}
+	total := p.Amount * float64(years)
+	return &total
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsRenewCmd = &cobra.Command{
+	Use:   "renew [domain...]",
+	Short: "Renew domains",
+	Long: `Renew one or more domains, or every domain expiring within a period with
+--all-expiring-within. The renewal cost is shown and the domains are only
+renewed after typing "yes", or with --auto-approve. Domains whose price is
+unknown are only renewed with --auto-approve, as is any renewal with --json;
+use --dry-run --json to get the quote as JSON. Renewals are charged to the
+account.`,
+	RunE: runDomainsRenew,
+}
+
+func init() {
+	domainsRenewCmd.Flags().Int("years", 1, "renewal period in years")
+	domainsRenewCmd.Flags().Bool("auto-approve", false, "renew without asking for confirmation")
+	addExpiringSelector(domainsRenewCmd)
+	addBulkFlags(domainsRenewCmd)
+
+	domainsCmd.AddCommand(domainsRenewCmd)
+}
+
+// renewal is a domain selected for renewal together with its quoted price.
+// ExpiresAt is nil when the API reports no expiry date.
+type renewal struct {
+	Domain    string            `json:"domain"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+	Years     int               `json:"years"`
+	Price     *infomaniak.Price `json:"price"`
+}
+
+func runDomainsRenew(cmd *cobra.Command, args []string) error {
+	years, err := cmd.Flags().GetInt("years")
+	if err != nil {
+		return fmt.Errorf("parse years flag: %w", err)
+	}
+	if years < 1 || years > 10 {
+		return fmt.Errorf("years must be between 1 and 10, got %d", years)
+	}
+
+	autoApprove, err := cmd.Flags().GetBool("auto-approve")
+	if err != nil {
+		return fmt.Errorf("parse auto-approve flag: %w", err)
+	}
+	if !autoApprove && !dryRun() && readsStdin(cmd, args) {
+		return fmt.Errorf("renew domains: domains read from stdin leave no input to confirm with; use --auto-approve")
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	if jsonOut && !autoApprove && !dryRun() {
+		return fmt.Errorf("renew domains: --json leaves no prompt to confirm with; use --auto-approve, or --dry-run to preview the renewal")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Minute)
+	defer cancel()
+
+	domains, err := selectDomains(ctx, cmd, client, args)
+	if err != nil {
+		return fmt.Errorf("renew domains: %w", err)
+	}
+
+	if len(domains) == 0 {
+		if jsonOut {
+			return printJSON([]renewal{})
+		}
+		fmt.Println("No domains to renew.")
+		return nil
+	}
+
+	renewals := make([]renewal, 0, len(domains))
+	for _, d := range domains {
+		price, err := client.RenewalPrice(ctx, d.Name, years)
+		if err != nil {
+			return fmt.Errorf("renew domains: %w", err)
+		}
+		r := renewal{Domain: d.Name, Years: years, Price: price}
+		if d.ExpiresAt != 0 {
+			expires := time.Unix(d.ExpiresAt, 0).UTC()
+			r.ExpiresAt = &expires
+		}
+		renewals = append(renewals, r)
+	}
+
+	// Only a dry run gets here with --json.
+	if jsonOut && !autoApprove {
+		return printJSON(renewals)
+	}
+
+	if !autoApprove {
+		w := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DOMAIN\tEXPIRES\tYEARS\tPRICE")
+		for _, r := range renewals {
+			expires := "-"
+			if r.ExpiresAt != nil {
+				expires = r.ExpiresAt.Format("2006-01-02")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", r.Domain, expires, r.Years, formatPrice(r.Price, 1))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		var unpriced []string
+		for _, r := range renewals {
+			if r.Price == nil {
+				unpriced = append(unpriced, r.Domain)
+			}
+		}
+		if len(unpriced) > 0 {
+			return fmt.Errorf("renew domains: the price of %s is unknown; use --auto-approve to renew anyway", strings.Join(unpriced, ", "))
+		}
+
+		ok, err := confirm(fmt.Sprintf("\nRenew %d domain(s) for %s in total?", len(renewals), renewalTotal(renewals)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("renewal cancelled")
+		}
+	}
+
+	names := make([]string, len(renewals))
+	for i, r := range renewals {
+		names[i] = r.Domain
+	}
+
+	return runDomains(cmd, names, func(ctx context.Context, domain string) (domainOutcome, error) {
+		err := previewDomain(ctx, client, domain, func(d *infomaniak.Domain) {
+			// Without a known expiry date the new one cannot be predicted.
+			if d.ExpiresAt != 0 {
+				d.ExpiresAt = time.Unix(d.ExpiresAt, 0).AddDate(years, 0, 0).Unix()
+			}
+		})
+		if err != nil {
+			return domainOutcome{}, fmt.Errorf("renew domain: %w", err)
+		}
+
+		d, err := client.RenewDomain(ctx, domain, infomaniak.RenewDomainInput{Years: years})
+		if err != nil {
+			return domainOutcome{}, fmt.Errorf("renew domain: %w", err)
+		}
+
+		message := fmt.Sprintf("Renewed %s for %d year(s).", domain, years)
+		if d.ExpiresAt != 0 {
+			message = fmt.Sprintf("Renewed %s until %s.", domain, time.Unix(d.ExpiresAt, 0).Format("2006-01-02"))
+		}
+		return domainOutcome{data: d, message: message}, nil
+	})
+}
+
+// renewalTotal sums the quoted prices per currency, e.g. "CHF 46.00".
+// Domains without a price are counted separately.
+func renewalTotal(renewals []renewal) string {
+	totals := map[string]float64{}
+	unknown := 0
+	for _, r := range renewals {
+		if r.Price == nil {
+			unknown++
+			continue
+		}
+		totals[r.Price.Currency] += r.Price.Amount
+	}
+
+	parts := make([]string, 0, len(totals))
+	for currency, amount := range totals {
+		parts = append(parts, fmt.Sprintf("%s %.2f", currency, amount))
+	}
+	sort.Strings(parts)
+	if unknown > 0 {
+		parts = append(parts, fmt.Sprintf("%d domain(s) at unknown price", unknown))
+	}
+	return strings.Join(parts, " + ")
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+// addExpiringSelector registers --all-expiring-within on commands that act
+// on a set of domains.
+func addExpiringSelector(cmd *cobra.Command) {
+	cmd.Flags().String("all-expiring-within", "", "act on every domain expiring within this period (e.g. 30d) instead of named domains")
+}
+
+// selectDomains resolves the domains a bulk command acts on: either the
+// domains given as for domainArgs or, with --all-expiring-within, every
+// domain of the account that expires before the cutoff, soonest first.
+func selectDomains(ctx context.Context, cmd *cobra.Command, client *infomaniak.Client, names []string) ([]infomaniak.Domain, error) {
+	within, err := cmd.Flags().GetString("all-expiring-within")
+	if err != nil {
+		return nil, fmt.Errorf("parse all-expiring-within flag: %w", err)
+	}
+
+	if within == "" {
+		names, err := domainArgs(cmd, names)
+		if err != nil {
+			return nil, err
+		}
+		domains := make([]infomaniak.Domain, 0, len(names))
+		for _, name := range names {
+			d, err := client.ShowDomain(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			domains = append(domains, *d)
+		}
+		return domains, nil
+	}
+
+	if len(names) > 0 || cmd.Flags().Changed("from-file") {
+		return nil, fmt.Errorf("--all-expiring-within cannot be combined with domain names")
+	}
+
+	period, err := parseDuration(within)
+	if err != nil {
+		return nil, fmt.Errorf("parse all-expiring-within flag: %w", err)
+	}
+	cutoff := time.Now().Add(period).Unix()
+
+	all, err := client.ListDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var domains []infomaniak.Domain
+	for _, d := range all {
+		if d.ExpiresAt > 0 && d.ExpiresAt <= cutoff {
+			domains = append(domains, d)
+		}
+	}
+	sort.Slice(domains, func(i, j int) bool { return domains[i].ExpiresAt < domains[j].ExpiresAt })
+
+	return domains, nil
+}
+
+// selectDomainNames is selectDomains for commands that only need the names;
+// named domains are not looked up.
+func selectDomainNames(ctx context.Context, cmd *cobra.Command, client *infomaniak.Client, names []string) ([]string, error) {
+	if !cmd.Flags().Changed("all-expiring-within") {
+		return domainArgs(cmd, names)
+	}
+
+	domains, err := selectDomains(ctx, cmd, client, names)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]string, len(domains))
+	for i, d := range domains {
+		selected[i] = d.Name
+	}
+	return selected, nil
+}
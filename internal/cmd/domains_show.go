@@ -2,15 +2,13 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"github.com/yannick/infomaniak/internal/api"
 )
 
 var domainsShowCmd = &cobra.Command{
@@ -25,13 +23,11 @@ func init() {
 }
 
 func runDomainsShow(cmd *cobra.Command, args []string) error {
-	token := viper.GetString("token")
-	if token == "" {
-		return fmt.Errorf("token is required: set via --token, config file, or $INFOMANIAK_TOKEN")
+	client, err := newClient()
+	if err != nil {
+		return err
 	}
 
-	client := api.NewClient(api.ClientConfig{Token: token})
-
 	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 	defer cancel()
 
@@ -45,9 +41,7 @@ func runDomainsShow(cmd *cobra.Command, args []string) error {
 
 	switch {
 	case jsonOut:
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(domain)
+		return printJSON(domain)
 	case simple:
 		fmt.Println(domain.Name)
 		return nil
@@ -55,12 +49,16 @@ func runDomainsShow(cmd *cobra.Command, args []string) error {
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 		fmt.Fprintf(w, "Name:\t%s\n", domain.Name)
 		fmt.Fprintf(w, "TLD:\t%s\n", domain.TLD)
+		fmt.Fprintf(w, "Status:\t%s\n", strings.Join(domain.Status, ", "))
 		fmt.Fprintf(w, "Premium:\t%v\n", domain.IsPremium)
 		fmt.Fprintf(w, "Created:\t%s\n", time.Unix(domain.CreatedAt, 0).Format("2006-01-02"))
 		fmt.Fprintf(w, "Expires:\t%s\n", time.Unix(domain.ExpiresAt, 0).Format("2006-01-02"))
 		fmt.Fprintf(w, "DNS Anycast:\t%v\n", domain.Options.DNSAnycast)
 		fmt.Fprintf(w, "DNSSEC:\t%v\n", domain.Options.DNSSEC)
 		fmt.Fprintf(w, "Domain Privacy:\t%v\n", domain.Options.DomainPrivacy)
+		fmt.Fprintf(w, "Auto-renew:\t%v\n", domain.AutoRenew)
+		fmt.Fprintf(w, "Transfer lock:\t%v\n", domain.TransferLocked())
+		fmt.Fprintf(w, "Nameservers:\t%s\n", strings.Join(domain.Nameservers, ", "))
 		return w.Flush()
 	}
 }
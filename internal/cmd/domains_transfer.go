@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var domainsTransferCmd = &cobra.Command{
+	Use:   "transfer",
+	Short: "Follow inbound domain transfers",
+}
+
+func init() {
+	domainsCmd.AddCommand(domainsTransferCmd)
+}
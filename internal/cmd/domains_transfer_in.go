@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsTransferInCmd = &cobra.Command{
+	Use:   "transfer-in <name>",
+	Short: "Transfer a domain in from another registrar",
+	Long: `Transfer a domain in from another registrar using the EPP authorization code
+issued by the current registrar. Without --auth-code, the code is read from
+the terminal without echo, or from stdin when it is piped.
+
+Progress can be followed with "infomaniak domains transfer status <name>".`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDomainsTransferIn,
+}
+
+func init() {
+	domainsTransferInCmd.Flags().String("auth-code", "", "EPP authorization code from the losing registrar")
+	addContactRoleFlags(domainsTransferInCmd)
+
+	domainsCmd.AddCommand(domainsTransferInCmd)
+}
+
+func runDomainsTransferIn(cmd *cobra.Command, args []string) error {
+	authCode, err := cmd.Flags().GetString("auth-code")
+	if err != nil {
+		return fmt.Errorf("parse auth-code flag: %w", err)
+	}
+
+	contacts, err := contactRoles(cmd)
+	if err != nil {
+		return err
+	}
+
+	if authCode == "" {
+		if authCode, err = promptSecret(fmt.Sprintf("Auth code for %s: ", args[0])); err != nil {
+			return err
+		}
+		if authCode == "" {
+			return fmt.Errorf("an auth code is required")
+		}
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	transfer, err := client.TransferIn(ctx, infomaniak.TransferInInput{
+		Name:     args[0],
+		AuthCode: authCode,
+		Contacts: contacts,
+	})
+	if err != nil {
+		return fmt.Errorf("transfer domain: %w", err)
+	}
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+
+	if jsonOut {
+		return printJSON(transfer)
+	}
+
+	fmt.Printf("Transfer of %s started (%s).\n", args[0], transfer.Status)
+	fmt.Printf("Follow it with: infomaniak domains transfer status %s --wait\n", args[0])
+	return nil
+}
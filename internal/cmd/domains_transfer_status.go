@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+var domainsTransferStatusCmd = &cobra.Command{
+	Use:   "status <name>",
+	Short: "Show the progress of an inbound transfer",
+	Long: `Show the progress of an inbound transfer. With --wait, the transfer is polled
+every --interval until it completes, fails or is cancelled; a transfer that
+does not complete makes the command fail.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDomainsTransferStatus,
+}
+
+func init() {
+	domainsTransferStatusCmd.Flags().Bool("wait", false, "poll until the transfer has finished")
+	domainsTransferStatusCmd.Flags().Duration("interval", time.Minute, "polling interval for --wait")
+	domainsTransferStatusCmd.Flags().Duration("timeout", 7*24*time.Hour, "give up waiting after this long")
+
+	domainsTransferCmd.AddCommand(domainsTransferStatusCmd)
+}
+
+func runDomainsTransferStatus(cmd *cobra.Command, args []string) error {
+	wait, err := cmd.Flags().GetBool("wait")
+	if err != nil {
+		return fmt.Errorf("parse wait flag: %w", err)
+	}
+
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		return fmt.Errorf("parse interval flag: %w", err)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return fmt.Errorf("parse timeout flag: %w", err)
+	}
+	if !wait {
+		timeout = 30 * time.Second
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	defer cancel()
+
+	jsonOut, _ := cmd.Flags().GetBool("json")
+	simple, _ := cmd.Flags().GetBool("simple")
+
+	var transfer *infomaniak.Transfer
+	for {
+		current, err := client.ShowTransfer(ctx, args[0])
+		if err != nil {
+			if !wait || ctx.Err() != nil || !transientError(err) {
+				return fmt.Errorf("transfer status: %w", err)
+			}
+			// A transfer takes days; one failed poll must not end the wait.
+			fmt.Fprintf(os.Stderr, "%s  %s: %v (retrying)\n", time.Now().Format(time.TimeOnly), args[0], err)
+		} else {
+			transfer = current
+			if !wait || transfer.Done() {
+				break
+			}
+			if !jsonOut && !simple {
+				fmt.Fprintf(os.Stderr, "%s  %s: %s\n", time.Now().Format(time.TimeOnly), transfer.Name, transfer.Status)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("transfer status: gave up waiting for %s: %w", args[0], ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+
+	switch {
+	case jsonOut:
+		if err := printJSON(transfer); err != nil {
+			return err
+		}
+	case simple:
+		fmt.Println(transfer.Status)
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Name:\t%s\n", transfer.Name)
+		fmt.Fprintf(w, "Status:\t%s\n", transfer.Status)
+		if transfer.Message != "" {
+			fmt.Fprintf(w, "Message:\t%s\n", transfer.Message)
+		}
+		fmt.Fprintf(w, "Started:\t%s\n", time.Unix(transfer.CreatedAt, 0).Format("2006-01-02 15:04"))
+		if transfer.UpdatedAt > 0 {
+			fmt.Fprintf(w, "Updated:\t%s\n", time.Unix(transfer.UpdatedAt, 0).Format("2006-01-02 15:04"))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if wait && transfer.Status != infomaniak.TransferCompleted {
+		return fmt.Errorf("transfer of %s %s", args[0], transfer.Status)
+	}
+	return nil
+}
+
+// transientError reports whether a failed poll is worth repeating: rate
+// limiting, server errors and network failures are, while errors about the
+// request itself, such as an unknown transfer, are not.
+func transientError(err error) bool {
+	var apiErr *infomaniak.Error
+	if !errors.As(err, &apiErr) {
+		return !errors.Is(err, context.Canceled)
+	}
+	return infomaniak.IsRateLimited(err) || apiErr.StatusCode >= 500
+}
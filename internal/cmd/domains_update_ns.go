@@ -2,37 +2,52 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"net"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"github.com/yannick/infomaniak/internal/api"
+	"github.com/yannick/infomaniak/internal/dnscheck"
+	"github.com/yannick/infomaniak/internal/state"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
 )
 
 var domainsUpdateNSCmd = &cobra.Command{
-	Use:   "update-ns <domain>",
-	Short: "Update nameservers for a domain",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDomainsUpdateNS,
+	Use:   "update-ns <domain>...",
+	Short: "Update nameservers for domains",
+	Long: `Update nameservers for domains.
+
+Before anything is changed, the new nameservers are queried directly: each
+must answer authoritatively for the domain, and all of them must serve the
+same SOA serial and NS records. The change is refused otherwise, unless
+--skip-checks is given. --resolver sets the resolver used to look up the
+nameservers' addresses.
+
+The nameservers a domain had before the change are saved, and
+--rollback restores them once: only the set replaced by the last change is
+kept, and it is removed when restored. With --wait, the command returns once the TLD's
+servers delegate to the new nameservers and these serve the zone.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runDomainsUpdateNS,
 }
 
 func init() {
 	domainsUpdateNSCmd.Flags().StringSlice("nameservers", nil, "comma-separated list of nameservers")
 	domainsUpdateNSCmd.Flags().Bool("verify", false, "verify nameserver availability before applying")
-	_ = domainsUpdateNSCmd.MarkFlagRequired("nameservers")
+	domainsUpdateNSCmd.Flags().Bool("rollback", false, "restore the nameservers saved by the last change")
+	domainsUpdateNSCmd.Flags().Bool("skip-checks", false, "apply without checking the new nameservers first")
+	addResolverFlag(domainsUpdateNSCmd)
+	addWaitFlags(domainsUpdateNSCmd)
+	domainsUpdateNSCmd.MarkFlagsMutuallyExclusive("nameservers", "rollback")
+	addBulkFlags(domainsUpdateNSCmd)
 
 	domainsCmd.AddCommand(domainsUpdateNSCmd)
 }
 
 func runDomainsUpdateNS(cmd *cobra.Command, args []string) error {
-	token := viper.GetString("token")
-	if token == "" {
-		return fmt.Errorf("token is required: set via --token, config file, or $INFOMANIAK_TOKEN")
-	}
-
 	nameservers, err := cmd.Flags().GetStringSlice("nameservers")
 	if err != nil {
 		return fmt.Errorf("parse nameservers flag: %w", err)
@@ -43,31 +58,163 @@ func runDomainsUpdateNS(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("parse verify flag: %w", err)
 	}
 
-	client := api.NewClient(api.ClientConfig{Token: token})
+	rollback, err := cmd.Flags().GetBool("rollback")
+	if err != nil {
+		return fmt.Errorf("parse rollback flag: %w", err)
+	}
+	if !rollback && len(nameservers) == 0 {
+		return fmt.Errorf("either --nameservers or --rollback is required")
+	}
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
-	defer cancel()
+	skipChecks, err := cmd.Flags().GetBool("skip-checks")
+	if err != nil {
+		return fmt.Errorf("parse skip-checks flag: %w", err)
+	}
+
+	wait, interval, timeout, err := waitFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	checker, err := nameserverChecker(cmd)
+	if err != nil {
+		return err
+	}
+
+	domains, err := domainArgs(cmd, args)
+	if err != nil {
+		return err
+	}
 
-	input := api.UpdateNameserversInput{
-		Nameservers:          nameservers,
-		VerifyNSAvailability: verify,
+	history, err := state.DefaultNameservers()
+	if err != nil {
+		return err
 	}
 
-	if err := client.UpdateNameservers(ctx, args[0], input); err != nil {
-		return fmt.Errorf("update nameservers: %w", err)
+	client, err := newClient()
+	if err != nil {
+		return err
 	}
 
-	jsonOut, _ := cmd.Flags().GetBool("json")
+	var (
+		mu      sync.Mutex
+		updated = map[string][]string{}
+	)
+	err = runDomains(cmd, domains, func(ctx context.Context, domain string) (domainOutcome, error) {
+		target := nameservers
+		if rollback {
+			prev, ok, err := history.Previous(domain)
+			if err != nil {
+				return domainOutcome{}, err
+			}
+			if !ok {
+				return domainOutcome{}, fmt.Errorf("no saved nameservers for %s to roll back to", domain)
+			}
+			target = prev.Nameservers
+		}
+
+		if !skipChecks {
+			report := checker.CheckDelegation(ctx, domain, target)
+			for _, w := range report.Warnings {
+				fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", domain, w)
+			}
+			if err := report.Err(); err != nil {
+				return domainOutcome{}, fmt.Errorf("%w (use --skip-checks to apply anyway)", err)
+			}
+		}
+
+		if err := previewDomain(ctx, client, domain, func(d *infomaniak.Domain) {
+			d.Nameservers = target
+		}); err != nil {
+			return domainOutcome{}, fmt.Errorf("update nameservers: %w", err)
+		}
+
+		current, err := client.ShowDomain(ctx, domain)
+		if err != nil {
+			return domainOutcome{}, fmt.Errorf("update nameservers: %w", err)
+		}
 
-	if jsonOut {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(map[string]string{
-			"domain": args[0],
-			"status": "updated",
-		})
+		input := infomaniak.UpdateNameserversInput{
+			Nameservers:          target,
+			VerifyNSAvailability: verify,
+		}
+		if err := client.UpdateNameservers(ctx, domain, input); err != nil {
+			return domainOutcome{}, fmt.Errorf("update nameservers: %w", err)
+		}
+
+		// A rollback consumes the saved set instead of saving the one it
+		// abandons; otherwise a second rollback would flip straight back.
+		if rollback {
+			if err := history.Forget(domain); err != nil {
+				return domainOutcome{}, fmt.Errorf("nameservers for %s rolled back, but the saved set was not removed: %w", domain, err)
+			}
+		} else if err := history.Save(domain, current.Nameservers, time.Now()); err != nil {
+			return domainOutcome{}, fmt.Errorf("nameservers for %s updated, but the previous set was not saved: %w", domain, err)
+		}
+
+		mu.Lock()
+		updated[domain] = target
+		mu.Unlock()
+
+		message := fmt.Sprintf("Nameservers for %s updated successfully.", domain)
+		if rollback {
+			message = fmt.Sprintf("Nameservers for %s rolled back to %s.", domain, strings.Join(target, ", "))
+		}
+		return domainOutcome{
+			data: map[string]any{
+				"domain":               domain,
+				"status":               "updated",
+				"nameservers":          target,
+				"previous_nameservers": current.Nameservers,
+			},
+			message: message,
+		}, nil
+	})
+	if !wait || len(updated) == 0 {
+		return err
 	}
 
-	fmt.Printf("Nameservers for %s updated successfully.\n", args[0])
-	return nil
+	// Wait for the domains that were updated even if others failed, so a
+	// pipeline using --continue-on-error does not go on before DNS is live.
+	// All domains share one deadline.
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	defer cancel()
+
+	var waitErr error
+	for _, domain := range domains {
+		target, ok := updated[domain]
+		if !ok {
+			continue
+		}
+		watch := dnscheck.Watch{Zone: domain, Nameservers: target}
+		if _, waitErr = waitForPropagation(ctx, cmd, checker, watch, interval); waitErr != nil {
+			break
+		}
+	}
+
+	switch {
+	case err == nil:
+		return waitErr
+	case waitErr != nil:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", waitErr)
+	}
+	return err
+}
+
+// nameserverChecker returns the DNS client for the pre-flight checks, using
+// --resolver if given.
+func nameserverChecker(cmd *cobra.Command) (*dnscheck.Client, error) {
+	resolver, err := cmd.Flags().GetString("resolver")
+	if err != nil {
+		return nil, fmt.Errorf("parse resolver flag: %w", err)
+	}
+
+	checker := &dnscheck.Client{}
+	if resolver != "" {
+		if _, _, err := net.SplitHostPort(resolver); err != nil {
+			resolver = net.JoinHostPort(resolver, "53")
+		}
+		checker.Resolver = dnscheck.NewResolver(resolver)
+	}
+	return checker, nil
 }
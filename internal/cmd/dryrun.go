@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+// dryRun reports whether --dry-run is in effect.
+func dryRun() bool {
+	return viper.GetBool("dry_run")
+}
+
+// dryRunOutput is where dry-run descriptions are written: stdout, unless
+// stdout carries --json output.
+func dryRunOutput() io.Writer {
+	if jsonOut, _ := rootCmd.PersistentFlags().GetBool("json"); jsonOut {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// previewDomain prints, in dry-run mode, how a change would alter a domain:
+// the current state is fetched and compared field by field with the state
+// after apply. It does nothing outside dry-run mode.
+func previewDomain(ctx context.Context, client *infomaniak.Client, name string, apply func(d *infomaniak.Domain)) error {
+	if !dryRun() {
+		return nil
+	}
+
+	before, err := client.ShowDomain(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	after := *before
+	after.Status = append([]string(nil), before.Status...)
+	after.Nameservers = append([]string(nil), before.Nameservers...)
+	apply(&after)
+
+	changes, err := diffFields(before, &after)
+	if err != nil {
+		return fmt.Errorf("preview %s: %w", name, err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "DRY RUN: %s\n", name)
+	if len(changes) == 0 {
+		b.WriteString("  no change\n")
+	}
+	for _, c := range changes {
+		fmt.Fprintf(&b, "  %s: %s -> %s\n", c.field, c.before, c.after)
+	}
+	b.WriteString("\n")
+
+	_, err = io.WriteString(dryRunOutput(), b.String())
+	return err
+}
+
+type fieldChange struct {
+	field, before, after string
+}
+
+// diffFields compares the JSON representations of two values and returns the
+// leaf fields that differ, by dotted path. Arrays are compared as a whole.
+func diffFields(before, after any) ([]fieldChange, error) {
+	b, err := flattenJSON(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := flattenJSON(after)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]bool{}
+	for k := range b {
+		keys[k] = true
+	}
+	for k := range a {
+		keys[k] = true
+	}
+
+	var changes []fieldChange
+	for k := range keys {
+		if b[k] != a[k] {
+			changes = append(changes, fieldChange{field: k, before: orUnset(b[k]), after: orUnset(a[k])})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].field < changes[j].field })
+
+	return changes, nil
+}
+
+func flattenJSON(v any) (map[string]string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+
+	out := map[string]string{}
+	var walk func(prefix string, v any)
+	walk = func(prefix string, v any) {
+		if m, ok := v.(map[string]any); ok {
+			for k, child := range m {
+				if prefix != "" {
+					k = prefix + "." + k
+				}
+				walk(k, child)
+			}
+			return
+		}
+		leaf, _ := json.Marshal(v)
+		out[prefix] = string(leaf)
+	}
+	walk("", tree)
+
+	return out, nil
+}
+
+func orUnset(s string) string {
+	if s == "" {
+		return "(unset)"
+	}
+	return s
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import "fmt"
+
+// ExitError asks main to exit with Code, for commands whose exit status
+// carries meaning, such as monitoring checks. Err, when set, is printed
+// first; otherwise nothing more is printed.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yannick/infomaniak/internal/exporter"
+)
+
+var exporterCmd = &cobra.Command{
+	Use:   "exporter",
+	Short: "Serve domain and DNS metrics for Prometheus",
+	Long: `Serve domain and DNS metrics for Prometheus.
+
+The API is polled in the background every --interval and the latest results
+are served on /metrics, so scrapes never wait for the API. Use --rate-limit
+to keep polls of large portfolios under the API quota.`,
+	Args: cobra.NoArgs,
+	RunE: runExporter,
+}
+
+func init() {
+	exporterCmd.Flags().String("listen", ":9813", "address to serve metrics on")
+	exporterCmd.Flags().Duration("interval", 15*time.Minute, "how often to poll the API")
+	exporterCmd.Flags().Bool("records", false, "also export the DNS record count of each zone")
+
+	rootCmd.AddCommand(exporterCmd)
+}
+
+func runExporter(cmd *cobra.Command, _ []string) error {
+	listen, err := cmd.Flags().GetString("listen")
+	if err != nil {
+		return fmt.Errorf("parse listen flag: %w", err)
+	}
+
+	var cfg exporter.Config
+	if cfg.Interval, err = cmd.Flags().GetDuration("interval"); err != nil {
+		return fmt.Errorf("parse interval flag: %w", err)
+	}
+	if cfg.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	if cfg.Records, err = cmd.Flags().GetBool("records"); err != nil {
+		return fmt.Errorf("parse records flag: %w", err)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	exp := exporter.New(client, cfg)
+	go exp.Run(ctx)
+
+	srv := &http.Server{
+		Addr:              listen,
+		Handler:           exp.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		slog.Info("serving metrics", "address", listen, "interval", cfg.Interval)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("serve metrics: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("shut down metrics server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// stdin is shared by all prompts: a reader of its own per prompt would
+// buffer input piped for the prompts that follow.
+var stdin = bufio.NewReader(os.Stdin)
+
+// confirm asks the user to type "yes" before a destructive action proceeds.
+// Dry runs never change anything, so they proceed without asking.
+func confirm(prompt string) (bool, error) {
+	if dryRun() {
+		fmt.Fprintf(os.Stderr, "%s\n  Dry run: not asking for confirmation.\n\n", prompt)
+		return true, nil
+	}
+	return confirmFrom(stdin, os.Stderr, prompt)
+}
+
+// promptSecret reads a value without echoing it when stdin is a terminal,
+// and reads a plain line otherwise so values can be piped in.
+func promptSecret(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		line, err := stdin.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", fmt.Errorf("read input: %w", err)
+		}
+		return strings.TrimSpace(line), nil
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	value, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("read input: %w", err)
+	}
+	return strings.TrimSpace(string(value)), nil
+}
+
+func confirmFrom(in *bufio.Reader, out io.Writer, prompt string) (bool, error) {
+	fmt.Fprintf(out, "%s\n  Only 'yes' will be accepted to approve.\n\n  Enter a value: ", prompt)
+
+	line, err := in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("read confirmation: %w", err)
+	}
+
+	return strings.TrimSpace(line) == "yes", nil
+}
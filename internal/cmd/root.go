@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
 	"log/slog"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
 )
 
 var version = "dev"
@@ -21,18 +25,35 @@ var rootCmd = &cobra.Command{
 	Short:   "Manage Infomaniak domains",
 	Long:    "infomaniak is a CLI tool to manage domains and nameservers via the Infomaniak API.",
 	Version: version,
+	// Errors are printed by main so API error details can be included.
+	SilenceErrors: true,
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentPreRunE = loadProfile
 
 	rootCmd.PersistentFlags().String("config", "", "config file (default $HOME/.infomaniak.yaml)")
 	rootCmd.PersistentFlags().String("token", "", "Infomaniak API token")
+	rootCmd.PersistentFlags().String("account-id", "", "Infomaniak account ID")
+	rootCmd.PersistentFlags().String("profile", "", "named profile from the config file to use")
+	rootCmd.PersistentFlags().Int("retries", infomaniak.DefaultRetryPolicy.MaxAttempts-1, "how often to retry transient API failures (0 disables retries)")
+	rootCmd.PersistentFlags().Float64("rate-limit", 0, "maximum API requests per second (0 for unlimited)")
+	rootCmd.PersistentFlags().Bool("dry-run", false, "print the write requests that would be sent instead of sending them")
+	rootCmd.PersistentFlags().CountP("verbose", "v", "log HTTP requests to stderr (-vv adds headers and bodies)")
+	rootCmd.PersistentFlags().Bool("debug", false, "log HTTP requests with headers and bodies to stderr (same as -vv)")
 	rootCmd.PersistentFlags().Bool("json", false, "output as JSON")
 	rootCmd.PersistentFlags().Bool("simple", false, "output simplified plain text")
 	rootCmd.MarkFlagsMutuallyExclusive("json", "simple")
 
 	_ = viper.BindPFlag("token", rootCmd.PersistentFlags().Lookup("token"))
+	_ = viper.BindPFlag("account_id", rootCmd.PersistentFlags().Lookup("account-id"))
+	_ = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
+	_ = viper.BindPFlag("retries", rootCmd.PersistentFlags().Lookup("retries"))
+	_ = viper.BindPFlag("rate_limit", rootCmd.PersistentFlags().Lookup("rate-limit"))
+	_ = viper.BindPFlag("dry_run", rootCmd.PersistentFlags().Lookup("dry-run"))
+	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	_ = viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
 }
 
 func initConfig() {
@@ -55,14 +76,80 @@ func initConfig() {
 	viper.AutomaticEnv()
 
 	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok && !errors.Is(err, fs.ErrNotExist) {
 			slog.Warn("reading config file", "error", err)
 		}
 	}
+
+	setupLogging()
+
+	// A dry run ends at the first write with infomaniak.ErrDryRun, which is not a
+	// usage mistake.
+	if viper.GetBool("dry_run") {
+		rootCmd.SilenceUsage = true
+	}
+}
+
+// loadProfile applies the selected profile before a command runs. The config
+// commands manage the profiles themselves, so they work with a missing one.
+func loadProfile(cmd *cobra.Command, _ []string) error {
+	profile := viper.GetString("profile")
+	if profile == "" {
+		return nil
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == configCmd {
+			return nil
+		}
+	}
+	if err := applyProfile(profile); err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("load profile: %w", err)
+	}
+	return nil
+}
+
+// profileKeys maps the settings a profile may override to the flag that
+// takes precedence over it, if any.
+var profileKeys = map[string]string{
+	"token":      "token",
+	"account_id": "account-id",
+	"base_url":   "",
+}
+
+// applyProfile overlays the settings of the named profile on top of the
+// top-level config values. Flags and environment variables still win.
+func applyProfile(name string) error {
+	key := "profiles." + name
+	if !viper.IsSet(key) {
+		return fmt.Errorf("profile %q not found in config file", name)
+	}
+
+	for setting, flag := range profileKeys {
+		if flag != "" && rootCmd.PersistentFlags().Changed(flag) {
+			continue
+		}
+		if _, ok := os.LookupEnv("INFOMANIAK_" + strings.ToUpper(setting)); ok {
+			continue
+		}
+		if v := viper.GetString(key + "." + setting); v != "" {
+			viper.Set(setting, v)
+		}
+	}
+
+	return nil
 }
 
 // Execute runs the root command.
 func Execute() error {
 	rootCmd.Version = version
-	return rootCmd.Execute()
+	cmd, err := rootCmd.ExecuteC()
+
+	// Usage mistakes, such as an unknown flag, are caught by cobra before the
+	// check runs and must not exit with a code read as WARNING either.
+	var exitErr *ExitError
+	if err != nil && cmd == domainsExpiringCmd && !errors.As(err, &exitErr) {
+		return unknownResult(cmd, err)
+	}
+	return err
 }
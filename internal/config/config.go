@@ -0,0 +1,196 @@
+// Package config reads and edits the infomaniak YAML config file while
+// preserving comments and unrelated settings.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Profile holds the per-account settings of a named profile.
+type Profile struct {
+	Token     string `yaml:"token,omitempty"`
+	AccountID string `yaml:"account_id,omitempty"`
+	BaseURL   string `yaml:"base_url,omitempty"`
+}
+
+// File is an editable config file.
+type File struct {
+	path string
+	doc  yaml.Node
+}
+
+// DefaultPath returns the default config file location, ~/.infomaniak.yaml.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("find home directory: %w", err)
+	}
+	return filepath.Join(home, ".infomaniak.yaml"), nil
+}
+
+// Load reads the config file at path. A missing file yields an empty config
+// that is created on Save.
+func Load(path string) (*File, error) {
+	f := &File{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		f.doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &f.doc); err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	if f.doc.Kind == 0 {
+		f.doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if f.root().Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parse config file %s: top level must be a mapping", path)
+	}
+
+	return f, nil
+}
+
+// Path returns the location the file is read from and saved to.
+func (f *File) Path() string {
+	return f.path
+}
+
+// Save writes the config back to disk with permissions restricted to the
+// current user since it may contain tokens.
+func (f *File) Save() error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&f.doc); err != nil {
+		return fmt.Errorf("encode config file: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encode config file: %w", err)
+	}
+
+	if err := os.WriteFile(f.path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("write config file: %w", err)
+	}
+	return nil
+}
+
+// Active returns the name of the profile selected by the "profile" key.
+func (f *File) Active() string {
+	if n := lookup(f.root(), "profile"); n != nil {
+		return n.Value
+	}
+	return ""
+}
+
+// SetActive selects the profile used when no --profile flag is given.
+func (f *File) SetActive(name string) error {
+	if _, ok := f.Profiles()[name]; !ok {
+		return fmt.Errorf("profile %q not found in %s", name, f.path)
+	}
+	setScalar(f.root(), "profile", name)
+	return nil
+}
+
+// Profiles returns all profiles defined in the file.
+func (f *File) Profiles() map[string]Profile {
+	out := make(map[string]Profile)
+
+	profiles := lookup(f.root(), "profiles")
+	if profiles == nil || profiles.Kind != yaml.MappingNode {
+		return out
+	}
+	for i := 0; i+1 < len(profiles.Content); i += 2 {
+		var p Profile
+		if err := profiles.Content[i+1].Decode(&p); err == nil {
+			out[profiles.Content[i].Value] = p
+		}
+	}
+	return out
+}
+
+// ProfileNames returns the profile names in sorted order.
+func (f *File) ProfileNames() []string {
+	profiles := f.Profiles()
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetProfile creates or updates a profile. Empty fields of p leave the
+// existing values untouched.
+func (f *File) SetProfile(name string, p Profile) {
+	profiles := lookup(f.root(), "profiles")
+	if profiles == nil || profiles.Kind != yaml.MappingNode {
+		profiles = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setNode(f.root(), "profiles", profiles)
+	}
+
+	entry := lookup(profiles, name)
+	if entry == nil || entry.Kind != yaml.MappingNode {
+		entry = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setNode(profiles, name, entry)
+	}
+
+	fields := []struct{ key, value string }{
+		{"token", p.Token},
+		{"account_id", p.AccountID},
+		{"base_url", p.BaseURL},
+	}
+	for _, fld := range fields {
+		if fld.value != "" {
+			setScalar(entry, fld.key, fld.value)
+		}
+	}
+}
+
+func (f *File) root() *yaml.Node {
+	return f.doc.Content[0]
+}
+
+func lookup(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func setNode(m *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value,
+	)
+}
+
+func setScalar(m *yaml.Node, key, value string) {
+	if n := lookup(m, key); n != nil && n.Kind == yaml.ScalarNode {
+		n.Value = value
+		n.Tag = "!!str"
+		n.Style = yaml.DoubleQuotedStyle
+		return
+	}
+	setNode(m, key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Style: yaml.DoubleQuotedStyle})
+}
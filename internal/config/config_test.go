@@ -0,0 +1,103 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProfilesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := `# Infomaniak API token
+token: "legacy-token"
+rate_limit: 1
+profiles:
+  company:
+    token: "company-token" # keep me
+    account_id: "111"
+`
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if got := f.Profiles()["company"]; got.Token != "company-token" || got.AccountID != "111" {
+		t.Errorf("company profile = %+v", got)
+	}
+
+	f.SetProfile("personal", Profile{Token: "personal-token", BaseURL: "https://api.example"})
+	f.SetProfile("company", Profile{AccountID: "222"})
+	if err := f.SetActive("personal"); err != nil {
+		t.Fatalf("set active: %v", err)
+	}
+	if err := f.SetActive("missing"); err == nil {
+		t.Error("SetActive(missing) succeeded, want error")
+	}
+	if err := f.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# Infomaniak API token", "# keep me", "rate_limit: 1"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("saved config lost %q:\n%s", want, data)
+		}
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if got := reloaded.Active(); got != "personal" {
+		t.Errorf("active = %q, want %q", got, "personal")
+	}
+	if got := reloaded.ProfileNames(); strings.Join(got, ",") != "company,personal" {
+		t.Errorf("names = %v, want [company personal]", got)
+	}
+	company := reloaded.Profiles()["company"]
+	if company.Token != "company-token" || company.AccountID != "222" {
+		t.Errorf("company profile = %+v, want token kept and account id updated", company)
+	}
+	if got := reloaded.Profiles()["personal"].BaseURL; got != "https://api.example" {
+		t.Errorf("personal base url = %q", got)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("permissions = %v, want 0600", perm)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "new.yaml")
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(f.Profiles()) != 0 {
+		t.Errorf("profiles = %v, want none", f.Profiles())
+	}
+
+	f.SetProfile("default", Profile{Token: "tok"})
+	if err := f.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("config not created: %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+// Package credentials stores API tokens outside the plaintext config file,
+// in the OS keyring when one is available and in an age-encrypted file
+// otherwise.
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultProfile is the key tokens are stored under when no profile is used.
+const DefaultProfile = "default"
+
+// ErrNotFound is returned when no token is stored for a profile.
+var ErrNotFound = errors.New("no stored token")
+
+// Store persists one token per profile.
+type Store interface {
+	// Name describes where tokens are kept, for display to the user.
+	Name() string
+	Get(profile string) (string, error)
+	Set(profile, token string) error
+	Delete(profile string) error
+}
+
+// Passphrase returns the passphrase of the encrypted file store. create is
+// set when the file does not exist yet, so a prompt can ask for it twice.
+type Passphrase func(create bool) (string, error)
+
+// Open returns the OS keyring when it is usable and falls back to an
+// encrypted file in the user's config directory. passphrase is only called
+// when the file store needs to encrypt or decrypt.
+func Open(passphrase Passphrase) (Store, error) {
+	if kr := NewKeyring(); kr.Available() {
+		return kr, nil
+	}
+
+	path, err := DefaultFilePath()
+	if err != nil {
+		return nil, err
+	}
+	return NewFile(path, passphrase), nil
+}
+
+// Stores returns every store a token may have been saved in, in the order
+// they are searched: the OS keyring when it is usable, then the encrypted
+// file.
+func Stores(passphrase Passphrase) ([]Store, error) {
+	var stores []Store
+	if kr := NewKeyring(); kr.Available() {
+		stores = append(stores, kr)
+	}
+
+	path, err := DefaultFilePath()
+	if err != nil {
+		return nil, err
+	}
+	return append(stores, NewFile(path, passphrase)), nil
+}
+
+// Lookup returns the token stored for profile in the first of stores that
+// has one, together with that store. A token saved with "auth login --store
+// file" is thus found even when a keyring is available. ErrNotFound is
+// returned when no store has a token for profile.
+func Lookup(profile string, stores ...Store) (string, Store, error) {
+	for _, store := range stores {
+		token, err := store.Get(profile)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		return token, store, nil
+	}
+	return "", nil, ErrNotFound
+}
+
+// DefaultFilePath returns the location of the encrypted credentials file,
+// honouring $XDG_CONFIG_HOME.
+func DefaultFilePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("find config directory: %w", err)
+	}
+	return filepath.Join(dir, "infomaniak", "credentials.age"), nil
+}
@@ -0,0 +1,177 @@
+package credentials
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func staticPassphrase(p string) Passphrase {
+	return func(bool) (string, error) { return p, nil }
+}
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "nested", "credentials.age")
+	store := NewFile(path, staticPassphrase("correct horse"))
+	store.workFactor = 10
+
+	if _, err := store.Get(DefaultProfile); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get on missing file: err = %v, want ErrNotFound", err)
+	}
+
+	if err := store.Set(DefaultProfile, "tok-default"); err != nil {
+		t.Fatalf("set default: %v", err)
+	}
+	if err := store.Set("company", "tok-company"); err != nil {
+		t.Fatalf("set company: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("tok-company")) {
+		t.Fatal("credentials file contains the plaintext token")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("permissions = %v, want 0600", perm)
+	}
+
+	got, err := store.Get("company")
+	if err != nil || got != "tok-company" {
+		t.Errorf("Get(company) = %q, %v; want tok-company", got, err)
+	}
+
+	wrong := NewFile(path, staticPassphrase("wrong"))
+	if _, err := wrong.Get("company"); err == nil {
+		t.Error("Get with wrong passphrase succeeded, want error")
+	}
+
+	if err := store.Delete("company"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := store.Get("company"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after delete: err = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete("company"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second delete: err = %v, want ErrNotFound", err)
+	}
+	if got, err := store.Get(DefaultProfile); err != nil || got != "tok-default" {
+		t.Errorf("Get(default) = %q, %v; want tok-default", got, err)
+	}
+}
+
+func TestFileStorePassphrasePrompts(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "credentials.age")
+
+	// prompts records the create argument of every passphrase prompt.
+	var prompts []bool
+	answers := []string{"first", "second"}
+	passphrase := func(create bool) (string, error) {
+		prompts = append(prompts, create)
+		p := answers[0]
+		answers = answers[1:]
+		return p, nil
+	}
+
+	store := NewFile(path, passphrase)
+	store.workFactor = 10
+	if err := store.Set(DefaultProfile, "tok-default"); err != nil {
+		t.Fatalf("set default: %v", err)
+	}
+	if err := store.Set("company", "tok-company"); err != nil {
+		t.Fatalf("set company: %v", err)
+	}
+	if len(prompts) != 1 || !prompts[0] {
+		t.Fatalf("prompts when creating = %v, want one with create set", prompts)
+	}
+
+	// A second login asks once, without create, and must not re-encrypt the
+	// file under the answer of another prompt.
+	prompts = nil
+	answers = []string{"first", "second"}
+	store = NewFile(path, passphrase)
+	store.workFactor = 10
+	if err := store.Set("other", "tok-other"); err != nil {
+		t.Fatalf("set other: %v", err)
+	}
+	if len(prompts) != 1 || prompts[0] {
+		t.Fatalf("prompts when updating = %v, want one without create", prompts)
+	}
+
+	check := NewFile(path, staticPassphrase("first"))
+	if got, err := check.Get("other"); err != nil || got != "tok-other" {
+		t.Errorf("Get(other) = %q, %v; want tok-other under the first passphrase", got, err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	keyring.MockInit()
+
+	kr := NewKeyring()
+	file := NewFile(filepath.Join(t.TempDir(), "credentials.age"), staticPassphrase("pass"))
+	file.workFactor = 10
+
+	if _, _, err := Lookup("company", kr, file); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Lookup in empty stores: err = %v, want ErrNotFound", err)
+	}
+
+	// "auth login --store file" on a host that has a keyring.
+	if err := file.Set("company", "tok-file"); err != nil {
+		t.Fatalf("set in file: %v", err)
+	}
+	token, store, err := Lookup("company", kr, file)
+	if err != nil || token != "tok-file" || store != Store(file) {
+		t.Errorf("Lookup = %q, %v, %v; want tok-file from the file", token, store, err)
+	}
+
+	if err := kr.Set("company", "tok-keyring"); err != nil {
+		t.Fatalf("set in keyring: %v", err)
+	}
+	token, store, err = Lookup("company", kr, file)
+	if err != nil || token != "tok-keyring" || store != Store(kr) {
+		t.Errorf("Lookup = %q, %v, %v; want tok-keyring from the keyring", token, store, err)
+	}
+}
+
+func TestKeyringStore(t *testing.T) {
+	keyring.MockInit()
+
+	store := NewKeyring()
+	if !store.Available() {
+		t.Fatal("mock keyring not available")
+	}
+
+	if _, err := store.Get("company"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get missing: err = %v, want ErrNotFound", err)
+	}
+	if err := store.Set("company", "tok"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if got, err := store.Get("company"); err != nil || got != "tok" {
+		t.Errorf("Get = %q, %v; want tok", got, err)
+	}
+	if err := store.Delete("company"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := store.Delete("company"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second delete: err = %v, want ErrNotFound", err)
+	}
+
+	keyring.MockInitWithError(errors.New("no dbus"))
+	if store.Available() {
+		t.Error("Available = true with failing keyring, want false")
+	}
+}
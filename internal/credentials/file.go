@@ -0,0 +1,160 @@
+package credentials
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"filippo.io/age"
+)
+
+// File stores tokens as JSON encrypted with an age passphrase, so the file
+// can also be decrypted by hand with `age -d`. The passphrase is asked for
+// at most once per File and reused to encrypt the file again.
+type File struct {
+	path       string
+	passphrase Passphrase
+	pass       string
+	// workFactor overrides age's default scrypt cost; only tests set it.
+	workFactor int
+}
+
+// NewFile returns a Store backed by the encrypted file at path.
+func NewFile(path string, passphrase Passphrase) *File {
+	return &File{path: path, passphrase: passphrase}
+}
+
+// Name implements Store.
+func (f *File) Name() string {
+	return "encrypted file " + f.path
+}
+
+// Get implements Store.
+func (f *File) Get(profile string) (string, error) {
+	tokens, err := f.load()
+	if err != nil {
+		return "", err
+	}
+	token, ok := tokens[profile]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return token, nil
+}
+
+// Set implements Store.
+func (f *File) Set(profile, token string) error {
+	tokens, err := f.load()
+	if err != nil {
+		return err
+	}
+	tokens[profile] = token
+	return f.save(tokens)
+}
+
+// Delete implements Store.
+func (f *File) Delete(profile string) error {
+	tokens, err := f.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[profile]; !ok {
+		return ErrNotFound
+	}
+	delete(tokens, profile)
+	return f.save(tokens)
+}
+
+func (f *File) load() (map[string]string, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read credentials file: %w", err)
+	}
+
+	pass, err := f.getPassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := age.NewScryptIdentity(pass)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt credentials file: %w", err)
+	}
+
+	r, err := age.Decrypt(bytes.NewReader(data), identity)
+	if err != nil {
+		f.pass = ""
+		return nil, fmt.Errorf("decrypt credentials file (wrong passphrase?): %w", err)
+	}
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt credentials file: %w", err)
+	}
+
+	tokens := map[string]string{}
+	if err := json.Unmarshal(plain, &tokens); err != nil {
+		return nil, fmt.Errorf("decode credentials file: %w", err)
+	}
+	return tokens, nil
+}
+
+// getPassphrase returns the cached passphrase, asking for it on first use.
+func (f *File) getPassphrase(create bool) (string, error) {
+	if f.pass != "" {
+		return f.pass, nil
+	}
+	pass, err := f.passphrase(create)
+	if err != nil {
+		return "", err
+	}
+	f.pass = pass
+	return pass, nil
+}
+
+func (f *File) save(tokens map[string]string) error {
+	plain, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("encode credentials: %w", err)
+	}
+
+	// Without a cached passphrase the file did not exist when it was
+	// loaded, so it is being created.
+	pass, err := f.getPassphrase(true)
+	if err != nil {
+		return err
+	}
+	recipient, err := age.NewScryptRecipient(pass)
+	if err != nil {
+		return fmt.Errorf("encrypt credentials file: %w", err)
+	}
+	if f.workFactor > 0 {
+		recipient.SetWorkFactor(f.workFactor)
+	}
+
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipient)
+	if err != nil {
+		return fmt.Errorf("encrypt credentials file: %w", err)
+	}
+	if _, err := w.Write(plain); err != nil {
+		return fmt.Errorf("encrypt credentials file: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("encrypt credentials file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return fmt.Errorf("create credentials directory: %w", err)
+	}
+	if err := os.WriteFile(f.path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("write credentials file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keyringService is the service name tokens are stored under.
+const keyringService = "infomaniak-cli"
+
+// Keyring stores tokens in the Secret Service on Linux, the Keychain on
+// macOS and the Credential Manager on Windows.
+type Keyring struct{}
+
+// NewKeyring returns a keyring-backed Store.
+func NewKeyring() *Keyring {
+	return &Keyring{}
+}
+
+// Available reports whether the OS keyring can be reached, e.g. false on a
+// headless Linux machine without a Secret Service daemon.
+func (k *Keyring) Available() bool {
+	_, err := keyring.Get(keyringService, "availability-probe")
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
+
+// Name implements Store.
+func (k *Keyring) Name() string {
+	return "OS keyring"
+}
+
+// Get implements Store.
+func (k *Keyring) Get(profile string) (string, error) {
+	token, err := keyring.Get(keyringService, profile)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("read token from keyring: %w", err)
+	}
+	return token, nil
+}
+
+// Set implements Store.
+func (k *Keyring) Set(profile, token string) error {
+	if err := keyring.Set(keyringService, profile, token); err != nil {
+		return fmt.Errorf("store token in keyring: %w", err)
+	}
+	return nil
+}
+
+// Delete implements Store.
+func (k *Keyring) Delete(profile string) error {
+	err := keyring.Delete(keyringService, profile)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("delete token from keyring: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package dnscheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// Server is the outcome of checking one address of a nameserver.
+type Server struct {
+	Nameserver string   `json:"nameserver"`
+	Address    string   `json:"address,omitempty"`
+	Serial     uint32   `json:"serial,omitempty"`
+	NS         []string `json:"ns,omitempty"`
+	Problems   []string `json:"problems,omitempty"`
+	// Skipped is set when the address cannot be reached from this host at
+	// all, typically an IPv6 address without IPv6 connectivity.
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// Report is the outcome of checking a set of nameservers for a zone.
+// Problems make the delegation unsafe; warnings are worth a look but do not
+// break resolution.
+type Report struct {
+	Zone     string   `json:"zone"`
+	Servers  []Server `json:"servers"`
+	Problems []string `json:"problems,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// OK reports whether no problems were found.
+func (r *Report) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// Err returns the problems as a single error, or nil.
+func (r *Report) Err() error {
+	if r.OK() {
+		return nil
+	}
+	return fmt.Errorf("nameservers are not ready for %s: %s", r.Zone, strings.Join(r.Problems, "; "))
+}
+
+// CheckDelegation queries every address of the given nameservers for the
+// SOA and NS records of zone. Each must answer authoritatively, and all of
+// them must agree on the SOA serial and the NS set. Servers are queried
+// concurrently.
+//
+// Nameservers inside zone usually cannot be resolved before the delegation
+// and its glue exist. Their addresses are asked of the other proposed
+// servers instead, and if none knows them the server is skipped with a
+// warning.
+func (c *Client) CheckDelegation(ctx context.Context, zone string, nameservers []string) *Report {
+	zone = normalizeName(zone)
+	report := &Report{Zone: zone}
+
+	var inZone []string
+	for _, ns := range nameservers {
+		ns = normalizeName(ns)
+
+		addrs, err := c.LookupAddrs(ctx, ns)
+		if err != nil && isInZone(ns, zone) {
+			inZone = append(inZone, ns)
+			continue
+		}
+		if err != nil {
+			report.Servers = append(report.Servers, Server{Nameserver: ns, Problems: []string{err.Error()}})
+			continue
+		}
+		for _, addr := range addrs {
+			report.Servers = append(report.Servers, Server{Nameserver: ns, Address: addr})
+		}
+	}
+
+	known := make([]string, 0, len(report.Servers))
+	for _, s := range report.Servers {
+		if s.Address != "" {
+			known = append(known, s.Address)
+		}
+	}
+	for _, ns := range inZone {
+		addrs := c.glueAddrs(ctx, ns, known)
+		if len(addrs) == 0 {
+			report.Servers = append(report.Servers, Server{
+				Nameserver: ns,
+				Skipped:    "in-zone name has no address until the delegation and its glue exist",
+			})
+			continue
+		}
+		for _, addr := range addrs {
+			report.Servers = append(report.Servers, Server{Nameserver: ns, Address: addr})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range report.Servers {
+		s := &report.Servers[i]
+		if s.Address == "" {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.checkServer(ctx, zone, s)
+		}()
+	}
+	wg.Wait()
+
+	for _, s := range report.Servers {
+		label := s.Nameserver
+		if s.Address != "" && s.Address != s.Nameserver {
+			label += " (" + s.Address + ")"
+		}
+		if s.Skipped != "" {
+			report.Warnings = append(report.Warnings, label+": skipped, "+s.Skipped)
+		}
+		for _, p := range s.Problems {
+			report.Problems = append(report.Problems, label+": "+p)
+		}
+	}
+
+	report.compare(nameservers)
+	return report
+}
+
+// glueAddrs asks the nameservers at servers for the addresses of the in-zone
+// nameserver ns, returning the first authoritative answer.
+func (c *Client) glueAddrs(ctx context.Context, ns string, servers []string) []string {
+	for _, server := range servers {
+		var addrs []string
+		for _, qtype := range []dnsmessage.Type{dnsmessage.TypeA, dnsmessage.TypeAAAA} {
+			answer, err := c.Query(ctx, server, ns, qtype)
+			if err != nil || !answer.Authoritative || answer.RCode != dnsmessage.RCodeSuccess {
+				continue
+			}
+			for _, rr := range answer.Answers {
+				switch body := rr.Body.(type) {
+				case *dnsmessage.AResource:
+					addrs = append(addrs, net.IP(body.A[:]).String())
+				case *dnsmessage.AAAAResource:
+					addrs = append(addrs, net.IP(body.AAAA[:]).String())
+				}
+			}
+		}
+		if len(addrs) > 0 {
+			return addrs
+		}
+	}
+	return nil
+}
+
+// isInZone reports whether name is zone or below it.
+func isInZone(name, zone string) bool {
+	return name == zone || strings.HasSuffix(name, "."+zone)
+}
+
+func (c *Client) checkServer(ctx context.Context, zone string, s *Server) {
+	soa, err := c.authoritative(ctx, s.Address, zone, dnsmessage.TypeSOA)
+	if unreachable(err) {
+		s.Skipped = "address unreachable from this host"
+		return
+	}
+	if err != nil {
+		s.Problems = append(s.Problems, err.Error())
+		return
+	}
+	// 0 is a valid serial, so whether the zone has an SOA record is decided
+	// by its presence.
+	hasSOA := false
+	for _, rr := range soa {
+		if body, ok := rr.Body.(*dnsmessage.SOAResource); ok {
+			s.Serial = body.Serial
+			hasSOA = true
+		}
+	}
+	if !hasSOA {
+		s.Problems = append(s.Problems, "no SOA record")
+	}
+
+	nsRecords, err := c.authoritative(ctx, s.Address, zone, dnsmessage.TypeNS)
+	if err != nil {
+		s.Problems = append(s.Problems, err.Error())
+		return
+	}
+	for _, rr := range nsRecords {
+		if body, ok := rr.Body.(*dnsmessage.NSResource); ok {
+			s.NS = append(s.NS, normalizeName(body.NS.String()))
+		}
+	}
+	sort.Strings(s.NS)
+	if len(s.NS) == 0 {
+		s.Problems = append(s.Problems, "no NS records")
+	}
+}
+
+// authoritative queries addr and returns the answers of an authoritative
+// response for the zone.
+func (c *Client) authoritative(ctx context.Context, addr, zone string, qtype dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	answer, err := c.Query(ctx, addr, zone, qtype)
+	if err != nil {
+		var netErr interface{ Timeout() bool }
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil, fmt.Errorf("no answer to %s query", typeName(qtype))
+		}
+		return nil, err
+	}
+	if answer.RCode != dnsmessage.RCodeSuccess {
+		return nil, fmt.Errorf("%s query answered %s", typeName(qtype), rcodeName(answer.RCode))
+	}
+	if !answer.Authoritative {
+		return nil, fmt.Errorf("not authoritative for %s", zone)
+	}
+	return answer.Answers, nil
+}
+
+// compare checks that the servers that answered agree with each other and
+// with the proposed delegation.
+func (r *Report) compare(proposed []string) {
+	var answered []Server
+	for _, s := range r.Servers {
+		if len(s.Problems) == 0 && s.Skipped == "" {
+			answered = append(answered, s)
+		}
+	}
+	if len(answered) == 0 {
+		// Every server was skipped, so nothing vouches for the delegation.
+		if len(r.Problems) == 0 {
+			r.Problems = append(r.Problems, "no proposed nameserver could be verified")
+		}
+		return
+	}
+
+	first := answered[0]
+	for _, s := range answered[1:] {
+		if s.Serial != first.Serial {
+			r.Problems = append(r.Problems, fmt.Sprintf("SOA serials differ: %s has %d, %s has %d",
+				first.Nameserver, first.Serial, s.Nameserver, s.Serial))
+			break
+		}
+	}
+	for _, s := range answered[1:] {
+		if !slices.Equal(s.NS, first.NS) {
+			r.Problems = append(r.Problems, fmt.Sprintf("NS records differ: %s serves %s, %s serves %s",
+				first.Nameserver, strings.Join(first.NS, ", "), s.Nameserver, strings.Join(s.NS, ", ")))
+			break
+		}
+	}
+
+	want := make([]string, len(proposed))
+	for i, ns := range proposed {
+		want[i] = normalizeName(ns)
+	}
+	sort.Strings(want)
+	if !slices.Equal(want, first.NS) {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("zone lists NS %s but the delegation would be %s",
+			strings.Join(first.NS, ", "), strings.Join(want, ", ")))
+	}
+}
+
+func rcodeName(rc dnsmessage.RCode) string {
+	return strings.TrimPrefix(rc.String(), "RCode")
+}
@@ -0,0 +1,345 @@
+package dnscheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// standIn is a minimal authoritative nameserver for one zone. It also
+// answers A and NS queries from hosts and delegations so it can stand in
+// for the resolver, and returns referrals so it can stand in for a parent
+// zone's server. A glueOnly server is not known to the resolver, like an
+// in-zone nameserver before its delegation exists.
+type standIn struct {
+	glueOnly      bool
+	zone          string
+	serial        uint32
+	ns            []string
+	records       []dnsmessage.Resource
+	authoritative bool
+	rcode         dnsmessage.RCode
+	hosts         map[string]string
+	delegations   map[string][]string
+	referrals     map[string][]string
+}
+
+// serve answers queries on conn until it is closed.
+func (s standIn) serve(conn net.PacketConn) {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		var req dnsmessage.Message
+		if err := req.Unpack(buf[:n]); err != nil || len(req.Questions) != 1 {
+			continue
+		}
+		msg := s.answer(req)
+		resp, err := msg.Pack()
+		if err != nil {
+			continue
+		}
+		_, _ = conn.WriteTo(resp, addr)
+	}
+}
+
+func (s standIn) answer(req dnsmessage.Message) dnsmessage.Message {
+	q := req.Questions[0]
+	resp := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:            req.Header.ID,
+			Response:      true,
+			Authoritative: s.authoritative,
+			RCode:         s.rcode,
+		},
+		Questions: req.Questions,
+	}
+	hdr := dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: 3600}
+
+	name := normalizeName(q.Name.String())
+	if ip, ok := s.hosts[name]; ok {
+		if q.Type == dnsmessage.TypeA {
+			var a dnsmessage.AResource
+			copy(a.A[:], net.ParseIP(ip).To4())
+			resp.Answers = append(resp.Answers, dnsmessage.Resource{Header: hdr, Body: &a})
+		}
+		return resp
+	}
+	if ns, ok := s.delegations[name]; ok {
+		if q.Type == dnsmessage.TypeNS {
+			resp.Answers = nsResources(hdr, ns)
+		}
+		return resp
+	}
+	if ns, ok := s.referrals[name]; ok {
+		resp.Authorities = nsResources(hdr, ns)
+		return resp
+	}
+	for _, rr := range s.records {
+		if rr.Header.Type == q.Type && strings.EqualFold(rr.Header.Name.String(), q.Name.String()) {
+			resp.Answers = append(resp.Answers, rr)
+		}
+	}
+	if s.rcode != dnsmessage.RCodeSuccess || !strings.EqualFold(q.Name.String(), fqdn(s.zone)) {
+		return resp
+	}
+
+	switch q.Type {
+	case dnsmessage.TypeSOA:
+		resp.Answers = append(resp.Answers, dnsmessage.Resource{Header: hdr, Body: &dnsmessage.SOAResource{
+			NS:     dnsmessage.MustNewName(fqdn(s.ns[0])),
+			MBox:   dnsmessage.MustNewName("hostmaster." + fqdn(s.zone)),
+			Serial: s.serial,
+		}})
+	case dnsmessage.TypeNS:
+		resp.Answers = nsResources(hdr, s.ns)
+	}
+	return resp
+}
+
+func nsResources(hdr dnsmessage.ResourceHeader, names []string) []dnsmessage.Resource {
+	hdr.Type = dnsmessage.TypeNS
+	var rrs []dnsmessage.Resource
+	for _, ns := range names {
+		rrs = append(rrs, dnsmessage.Resource{Header: hdr, Body: &dnsmessage.NSResource{
+			NS: dnsmessage.MustNewName(fqdn(ns)),
+		}})
+	}
+	return rrs
+}
+
+// startServers serves each named stand-in on its own loopback address, all
+// on the same port, and returns a client that resolves the names through
+// resolver.
+func startServers(t *testing.T, servers map[string]standIn, resolver standIn) *Client {
+	t.Helper()
+
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resolver.hosts = make(map[string]string)
+	port := "0"
+	for i, name := range names {
+		addr := net.IPv4(127, 0, 0, byte(i+1)).String()
+		conn, err := net.ListenPacket("udp", net.JoinHostPort(addr, port))
+		if err != nil {
+			t.Skipf("cannot listen on %s: %v", addr, err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		go servers[name].serve(conn)
+
+		if port == "0" {
+			_, port, _ = net.SplitHostPort(conn.LocalAddr().String())
+		}
+		if !servers[name].glueOnly {
+			resolver.hosts[name] = addr
+		}
+	}
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	go resolver.serve(conn)
+
+	return &Client{
+		Port:     port,
+		Timeout:  time.Second,
+		Resolver: NewResolver(conn.LocalAddr().String()),
+	}
+}
+
+// startStandIns serves the i-th stand-in as ns<i+1>.example.net.
+func startStandIns(t *testing.T, servers ...standIn) *Client {
+	t.Helper()
+
+	named := make(map[string]standIn)
+	for i, s := range servers {
+		named[fmt.Sprintf("ns%d.example.net", i+1)] = s
+	}
+	return startServers(t, named, standIn{})
+}
+
+func TestCheckDelegation(t *testing.T) {
+	t.Parallel()
+
+	good := standIn{
+		zone:          "example.ch",
+		serial:        2024010101,
+		ns:            []string{"ns1.example.net", "ns2.example.net"},
+		authoritative: true,
+	}
+	withSerial := func(s standIn, serial uint32) standIn { s.serial = serial; return s }
+	withNS := func(s standIn, ns ...string) standIn { s.ns = ns; return s }
+
+	tests := []struct {
+		name         string
+		servers      []standIn
+		wantProblems []string
+		wantWarnings []string
+	}{
+		{
+			name:    "consistent",
+			servers: []standIn{good, good},
+		},
+		{
+			name:         "not authoritative",
+			servers:      []standIn{good, {zone: "example.ch", ns: good.ns}},
+			wantProblems: []string{"not authoritative for example.ch"},
+		},
+		{
+			name:         "refused",
+			servers:      []standIn{good, {rcode: dnsmessage.RCodeRefused}},
+			wantProblems: []string{"SOA query answered Refused"},
+		},
+		{
+			name:         "serial mismatch",
+			servers:      []standIn{good, withSerial(good, 2024010102)},
+			wantProblems: []string{"SOA serials differ"},
+		},
+		{
+			name:         "ns mismatch",
+			servers:      []standIn{good, withNS(good, "ns1.example.net")},
+			wantProblems: []string{"NS records differ"},
+		},
+		{
+			name:         "zone ns differ from delegation",
+			servers:      []standIn{withNS(good, "ns1.other.net"), withNS(good, "ns1.other.net")},
+			wantWarnings: []string{"zone lists NS ns1.other.net"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := startStandIns(t, tt.servers...)
+
+			report := c.CheckDelegation(context.Background(), "Example.CH.", good.ns)
+
+			if report.Zone != "example.ch" {
+				t.Errorf("Zone = %q, want example.ch", report.Zone)
+			}
+			if len(report.Servers) != len(tt.servers) {
+				t.Errorf("got %d servers, want %d", len(report.Servers), len(tt.servers))
+			}
+			assertMessages(t, "problems", report.Problems, tt.wantProblems)
+			assertMessages(t, "warnings", report.Warnings, tt.wantWarnings)
+			if report.OK() != (len(tt.wantProblems) == 0) {
+				t.Errorf("OK() = %v with problems %q", report.OK(), report.Problems)
+			}
+		})
+	}
+}
+
+func TestCheckDelegationInZone(t *testing.T) {
+	t.Parallel()
+
+	ns := []string{"ns1.example.ch", "ns2.example.net"}
+	inZone := standIn{glueOnly: true, zone: "example.ch", serial: 0, ns: ns, authoritative: true}
+	outside := standIn{zone: "example.ch", serial: 0, ns: ns, authoritative: true}
+	// The stand-ins get loopback addresses in name order.
+	withGlue := outside
+	withGlue.hosts = map[string]string{"ns1.example.ch": "127.0.0.1"}
+
+	tests := []struct {
+		name         string
+		outside      standIn
+		wantServers  int
+		wantWarnings []string
+	}{
+		{
+			name:        "address from the other proposed server",
+			outside:     withGlue,
+			wantServers: 2,
+		},
+		{
+			name:         "no address anywhere",
+			outside:      outside,
+			wantServers:  2,
+			wantWarnings: []string{"ns1.example.ch: skipped, in-zone name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := startServers(t, map[string]standIn{
+				"ns1.example.ch":  inZone,
+				"ns2.example.net": tt.outside,
+			}, standIn{})
+
+			report := c.CheckDelegation(context.Background(), "example.ch", ns)
+
+			if len(report.Servers) != tt.wantServers {
+				t.Errorf("got %d servers, want %d: %+v", len(report.Servers), tt.wantServers, report.Servers)
+			}
+			// Serial 0 is valid and must not count as a missing SOA record.
+			assertMessages(t, "problems", report.Problems, nil)
+			assertMessages(t, "warnings", report.Warnings, tt.wantWarnings)
+		})
+	}
+}
+
+func TestCheckDelegationNothingVerified(t *testing.T) {
+	t.Parallel()
+
+	ns := []string{"ns1.example.ch"}
+	c := startServers(t, map[string]standIn{
+		"ns1.example.ch": {glueOnly: true, zone: "example.ch", serial: 1, ns: ns, authoritative: true},
+	}, standIn{})
+
+	report := c.CheckDelegation(context.Background(), "example.ch", ns)
+	assertMessages(t, "problems", report.Problems, []string{"no proposed nameserver could be verified"})
+	if report.OK() {
+		t.Error("OK() = true although no nameserver was checked")
+	}
+}
+
+func TestCheckDelegationUnreachable(t *testing.T) {
+	t.Parallel()
+
+	// Bind a port and close it so nothing answers there.
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, port, _ := net.SplitHostPort(conn.LocalAddr().String())
+	conn.Close()
+
+	c := &Client{Port: port, Timeout: 200 * time.Millisecond}
+	report := c.CheckDelegation(context.Background(), "example.ch", []string{"127.0.0.1"})
+	if report.OK() {
+		t.Fatal("OK() = true for an unreachable nameserver")
+	}
+	if err := report.Err(); err == nil || !strings.Contains(err.Error(), "127.0.0.1") {
+		t.Errorf("Err() = %v, want the nameserver named", err)
+	}
+}
+
+func assertMessages(t *testing.T, kind string, got, want []string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Errorf("%s = %q, want %d matching %q", kind, got, len(want), want)
+		return
+	}
+	for i := range want {
+		if !strings.Contains(got[i], want[i]) {
+			t.Errorf("%s[%d] = %q, want it to contain %q", kind, i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,453 @@
+package dnscheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// Roles of the servers polled while waiting for a change.
+const (
+	RoleParent        = "parent"
+	RoleAuthoritative = "authoritative"
+)
+
+// Record is a DNS record whose publication is awaited.
+type Record struct {
+	// Name is the fully qualified owner name.
+	Name string `json:"name"`
+	Type Type   `json:"type"`
+	// Value is the record data in zone file notation, for example
+	// "10 mail.example.ch" for MX. An empty value matches any record of
+	// the type. Values are only compared for A, AAAA, CNAME, MX, NS, PTR,
+	// SRV and TXT records; other types match on presence.
+	Value string `json:"value,omitempty"`
+	// Absent waits for the record to disappear instead.
+	Absent bool `json:"absent,omitempty"`
+}
+
+func (r Record) String() string {
+	s := r.Name + " " + r.Type.String()
+	if r.Value != "" {
+		s += " " + r.Value
+	}
+	return s
+}
+
+// Watch describes a change to wait for.
+type Watch struct {
+	Zone string
+	// Nameservers is the delegation the parent zone's servers must return.
+	// When empty, the delegation is not checked and the records are
+	// awaited on whatever nameservers the parent delegates to.
+	Nameservers []string
+	// Records must be visible on every authoritative nameserver. Without
+	// records, the authoritative nameservers only have to serve the zone.
+	Records []Record
+}
+
+// ServerState is where one server stands in a propagation round.
+type ServerState struct {
+	Role       string `json:"role"`
+	Nameserver string `json:"nameserver"`
+	Address    string `json:"address,omitempty"`
+	Done       bool   `json:"done"`
+	Detail     string `json:"detail"`
+}
+
+// Progress is the outcome of one propagation round.
+type Progress struct {
+	Zone    string        `json:"zone"`
+	Servers []ServerState `json:"servers"`
+}
+
+// Done reports whether the change is visible on every server.
+func (p *Progress) Done() bool {
+	return len(p.Servers) > 0 && p.Pending() == 0
+}
+
+// Pending returns the number of servers the change is not visible on yet.
+func (p *Progress) Pending() int {
+	n := 0
+	for _, s := range p.Servers {
+		if !s.Done {
+			n++
+		}
+	}
+	return n
+}
+
+// Propagation polls the servers of the parent zone and the authoritative
+// nameservers once and reports which of them show the change. Poll it until
+// Done to wait for a change.
+func (c *Client) Propagation(ctx context.Context, w Watch) (*Progress, error) {
+	zone := normalizeName(w.Zone)
+	progress := &Progress{Zone: zone}
+
+	parents, err := c.parentServers(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	want := make([]string, len(w.Nameservers))
+	for i, ns := range w.Nameservers {
+		want[i] = normalizeName(ns)
+	}
+	sort.Strings(want)
+
+	parentStates := c.pollServers(ctx, RoleParent, parents, func(ctx context.Context, s *ServerState) []string {
+		delegation, err := c.delegation(ctx, s.Address, zone)
+		if err != nil {
+			setError(s, err)
+			return nil
+		}
+		switch {
+		case len(delegation) == 0:
+			s.Detail = "no delegation"
+		case len(want) > 0 && !slices.Equal(delegation, want):
+			s.Detail = "delegates to " + strings.Join(delegation, ", ")
+		default:
+			s.Done = true
+			s.Detail = "delegates to " + strings.Join(delegation, ", ")
+		}
+		return delegation
+	})
+	progress.Servers = append(progress.Servers, parentStates.states...)
+
+	authoritative := want
+	if len(authoritative) == 0 {
+		authoritative = parentStates.seen
+	}
+	if len(authoritative) == 0 {
+		return progress, nil
+	}
+
+	authStates := c.pollServers(ctx, RoleAuthoritative, authoritative, func(ctx context.Context, s *ServerState) []string {
+		c.checkRecords(ctx, zone, w.Records, s)
+		return nil
+	})
+	progress.Servers = append(progress.Servers, authStates.states...)
+
+	return progress, nil
+}
+
+type polled struct {
+	states []ServerState
+	// seen is the union of the names returned by the poll function.
+	seen []string
+}
+
+// pollServers resolves the addresses of the named servers and runs poll
+// on each of them concurrently.
+func (c *Client) pollServers(ctx context.Context, role string, names []string, poll func(context.Context, *ServerState) []string) polled {
+	var states []ServerState
+	for _, name := range names {
+		addrs, err := c.LookupAddrs(ctx, name)
+		if err != nil {
+			states = append(states, ServerState{Role: role, Nameserver: name, Detail: err.Error()})
+			continue
+		}
+		for _, addr := range addrs {
+			states = append(states, ServerState{Role: role, Nameserver: name, Address: addr})
+		}
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		seen = map[string]bool{}
+	)
+	for i := range states {
+		s := &states[i]
+		if s.Address == "" {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			names := poll(ctx, s)
+			mu.Lock()
+			for _, n := range names {
+				seen[n] = true
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	p := polled{states: states}
+	for n := range seen {
+		p.seen = append(p.seen, n)
+	}
+	sort.Strings(p.seen)
+	return p
+}
+
+// setError records a failed query. Addresses this host cannot reach at all
+// are counted as done so that a missing IPv6 route does not block forever.
+func setError(s *ServerState, err error) {
+	if unreachable(err) {
+		s.Done = true
+		s.Detail = "skipped, address unreachable from this host"
+		return
+	}
+	s.Detail = err.Error()
+}
+
+func unreachable(err error) bool {
+	return errors.Is(err, syscall.ENETUNREACH) || errors.Is(err, syscall.EHOSTUNREACH)
+}
+
+// parentServers returns the nameservers of the closest enclosing zone
+// that has any.
+func (c *Client) parentServers(ctx context.Context, zone string) ([]string, error) {
+	r := c.Resolver
+	if r == nil {
+		r = net.DefaultResolver
+	}
+
+	for parent := parentName(zone); parent != ""; parent = parentName(parent) {
+		records, err := r.LookupNS(ctx, fqdn(parent))
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("find nameservers of %s: %w", parent, err)
+		}
+
+		hosts := make([]string, len(records))
+		for i, ns := range records {
+			hosts[i] = normalizeName(ns.Host)
+		}
+		sort.Strings(hosts)
+		return hosts, nil
+	}
+	return nil, fmt.Errorf("find parent zone of %s: no enclosing zone has nameservers", zone)
+}
+
+// delegation asks a parent server for the NS records of zone. They come back
+// as a referral in the authority section, or as an answer from a server that
+// is authoritative for both zones.
+func (c *Client) delegation(ctx context.Context, addr, zone string) ([]string, error) {
+	answer, err := c.Query(ctx, addr, zone, dnsmessage.TypeNS)
+	if err != nil {
+		return nil, err
+	}
+	if answer.RCode != dnsmessage.RCodeSuccess {
+		return nil, fmt.Errorf("NS query answered %s", rcodeName(answer.RCode))
+	}
+
+	var names []string
+	for _, rr := range slices.Concat(answer.Answers, answer.Authority) {
+		body, ok := rr.Body.(*dnsmessage.NSResource)
+		if !ok || normalizeName(rr.Header.Name.String()) != zone {
+			continue
+		}
+		if n := normalizeName(body.NS.String()); !slices.Contains(names, n) {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// checkRecords queries an authoritative server for the awaited records, or
+// only for the zone's SOA when there are none.
+func (c *Client) checkRecords(ctx context.Context, zone string, records []Record, s *ServerState) {
+	if len(records) == 0 {
+		answers, err := c.authoritative(ctx, s.Address, zone, dnsmessage.TypeSOA)
+		if err != nil {
+			setError(s, err)
+			return
+		}
+		for _, rr := range answers {
+			if soa, ok := rr.Body.(*dnsmessage.SOAResource); ok {
+				s.Done = true
+				s.Detail = fmt.Sprintf("serves %s, serial %d", zone, soa.Serial)
+				return
+			}
+		}
+		s.Detail = "no SOA record"
+		return
+	}
+
+	var pending []string
+	for _, r := range records {
+		qtype := dnsmessage.Type(r.Type)
+		answer, err := c.Query(ctx, s.Address, r.Name, qtype)
+		if err != nil {
+			setError(s, err)
+			return
+		}
+		// A name that does not exist is what a deleted record looks like.
+		if answer.RCode != dnsmessage.RCodeSuccess && answer.RCode != dnsmessage.RCodeNameError {
+			s.Detail = fmt.Sprintf("%s query answered %s", r.Type, rcodeName(answer.RCode))
+			return
+		}
+		if !answer.Authoritative {
+			s.Detail = "not authoritative for " + zone
+			return
+		}
+
+		found := false
+		for _, rr := range answer.Answers {
+			if rr.Header.Type == qtype && normalizeName(rr.Header.Name.String()) == normalizeName(r.Name) &&
+				(r.Value == "" || valueMatches(rr.Body, r.Value)) {
+				found = true
+				break
+			}
+		}
+		switch {
+		case found && r.Absent:
+			pending = append(pending, "still has "+r.String())
+		case !found && !r.Absent:
+			pending = append(pending, "missing "+r.String())
+		}
+	}
+
+	if len(pending) > 0 {
+		s.Detail = strings.Join(pending, "; ")
+		return
+	}
+	s.Done = true
+	s.Detail = "up to date"
+}
+
+// valueMatches compares record data with a value in zone file notation.
+// Types whose data is not decoded always match.
+func valueMatches(body dnsmessage.ResourceBody, value string) bool {
+	// TXT data is case sensitive and may be given with or without quotes.
+	if txt, ok := body.(*dnsmessage.TXTResource); ok {
+		if !strings.HasPrefix(strings.TrimSpace(value), `"`) {
+			return strings.Join(txt.TXT, "") == value
+		}
+	}
+
+	got, ok := formatValue(body)
+	if !ok {
+		return true
+	}
+	return normalizeValue(got) == normalizeValue(value)
+}
+
+func formatValue(body dnsmessage.ResourceBody) (string, bool) {
+	switch b := body.(type) {
+	case *dnsmessage.AResource:
+		return net.IP(b.A[:]).String(), true
+	case *dnsmessage.AAAAResource:
+		return net.IP(b.AAAA[:]).String(), true
+	case *dnsmessage.CNAMEResource:
+		return b.CNAME.String(), true
+	case *dnsmessage.NSResource:
+		return b.NS.String(), true
+	case *dnsmessage.PTRResource:
+		return b.PTR.String(), true
+	case *dnsmessage.MXResource:
+		return fmt.Sprintf("%d %s", b.Pref, b.MX), true
+	case *dnsmessage.SRVResource:
+		return fmt.Sprintf("%d %d %d %s", b.Priority, b.Weight, b.Port, b.Target), true
+	case *dnsmessage.TXTResource:
+		return `"` + strings.Join(b.TXT, `" "`) + `"`, true
+	default:
+		return "", false
+	}
+}
+
+// normalizeValue makes record data comparable: case, trailing dots on
+// names, TXT quoting and splitting, IP address notation and spacing are
+// ignored.
+func normalizeValue(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, `"`) {
+		parts := strings.Split(s, `"`)
+		var b strings.Builder
+		for i := 1; i < len(parts); i += 2 {
+			b.WriteString(parts[i])
+		}
+		return b.String()
+	}
+	if ip := net.ParseIP(s); ip != nil {
+		return ip.String()
+	}
+
+	fields := strings.Fields(strings.ToLower(s))
+	for i, f := range fields {
+		fields[i] = strings.TrimSuffix(f, ".")
+	}
+	return strings.Join(fields, " ")
+}
+
+// parentName returns name without its first label, or "" for a top-level
+// name.
+func parentName(name string) string {
+	_, parent, ok := strings.Cut(name, ".")
+	if !ok {
+		return ""
+	}
+	return parent
+}
+
+// Type is a DNS record type that prints and parses as its mnemonic.
+type Type dnsmessage.Type
+
+// Record types dnsmessage has no constant for.
+var extraTypes = map[string]Type{
+	"DS":    43,
+	"SSHFP": 44,
+	"TLSA":  52,
+	"SVCB":  64,
+	"HTTPS": 65,
+	"CAA":   257,
+}
+
+// ParseType parses a record type mnemonic such as "MX", or the generic
+// "TYPE257" notation.
+func ParseType(s string) (Type, error) {
+	s = strings.ToUpper(s)
+	for _, t := range []dnsmessage.Type{
+		dnsmessage.TypeA, dnsmessage.TypeNS, dnsmessage.TypeCNAME, dnsmessage.TypeSOA,
+		dnsmessage.TypePTR, dnsmessage.TypeMX, dnsmessage.TypeTXT, dnsmessage.TypeAAAA,
+		dnsmessage.TypeSRV,
+	} {
+		if typeName(t) == s {
+			return Type(t), nil
+		}
+	}
+	if t, ok := extraTypes[s]; ok {
+		return t, nil
+	}
+	if rest, ok := strings.CutPrefix(s, "TYPE"); ok {
+		if n, err := strconv.ParseUint(rest, 10, 16); err == nil {
+			return Type(n), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown record type %q", s)
+}
+
+func (t Type) String() string {
+	for name, extra := range extraTypes {
+		if t == extra {
+			return name
+		}
+	}
+	name := typeName(dnsmessage.Type(t))
+	if _, err := strconv.Atoi(name); err == nil {
+		return "TYPE" + name
+	}
+	return name
+}
+
+// MarshalText encodes the type as its mnemonic.
+func (t Type) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
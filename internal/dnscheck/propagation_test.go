@@ -0,0 +1,175 @@
+package dnscheck
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func aRecord(name, ip string) dnsmessage.Resource {
+	var a dnsmessage.AResource
+	copy(a.A[:], net.ParseIP(ip).To4())
+	return dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(fqdn(name)), Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET},
+		Body:   &a,
+	}
+}
+
+func mxRecord(name string, pref uint16, host string) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(fqdn(name)), Type: dnsmessage.TypeMX, Class: dnsmessage.ClassINET},
+		Body:   &dnsmessage.MXResource{Pref: pref, MX: dnsmessage.MustNewName(fqdn(host))},
+	}
+}
+
+func TestPropagation(t *testing.T) {
+	t.Parallel()
+
+	nameservers := []string{"ns1.example.net", "ns2.example.net"}
+	zone := func(records ...dnsmessage.Resource) standIn {
+		return standIn{zone: "example.test", serial: 7, ns: nameservers, records: records, authoritative: true}
+	}
+	parent := func(delegation ...string) standIn {
+		return standIn{referrals: map[string][]string{"example.test": delegation}}
+	}
+	www := aRecord("www.example.test", "192.0.2.1")
+
+	tests := []struct {
+		name        string
+		parent      standIn
+		ns1, ns2    standIn
+		watch       Watch
+		wantPending []string
+	}{
+		{
+			name:   "delegation done",
+			parent: parent(nameservers...),
+			ns1:    zone(),
+			ns2:    zone(),
+			watch:  Watch{Zone: "example.test", Nameservers: []string{"NS2.example.net.", "ns1.example.net"}},
+		},
+		{
+			name:        "delegation pending",
+			parent:      parent("ns1.old.net", "ns2.old.net"),
+			ns1:         zone(),
+			ns2:         zone(),
+			watch:       Watch{Zone: "example.test", Nameservers: nameservers},
+			wantPending: []string{"a.nic.test: delegates to ns1.old.net, ns2.old.net"},
+		},
+		{
+			name:        "record missing on one server",
+			parent:      parent(nameservers...),
+			ns1:         zone(www),
+			ns2:         zone(),
+			watch:       Watch{Zone: "example.test", Records: []Record{{Name: "www.example.test", Type: Type(dnsmessage.TypeA), Value: "192.0.2.1"}}},
+			wantPending: []string{"ns2.example.net: missing www.example.test A 192.0.2.1"},
+		},
+		{
+			name:        "record has old value",
+			parent:      parent(nameservers...),
+			ns1:         zone(www),
+			ns2:         zone(www),
+			watch:       Watch{Zone: "example.test", Records: []Record{{Name: "www.example.test", Type: Type(dnsmessage.TypeA), Value: "192.0.2.2"}}},
+			wantPending: []string{"ns1.example.net: missing", "ns2.example.net: missing"},
+		},
+		{
+			name:   "record value normalized",
+			parent: parent(nameservers...),
+			ns1:    zone(mxRecord("example.test", 10, "mail.example.test")),
+			ns2:    zone(mxRecord("example.test", 10, "mail.example.test")),
+			watch:  Watch{Zone: "example.test", Records: []Record{{Name: "example.test", Type: Type(dnsmessage.TypeMX), Value: "10  Mail.Example.Test."}}},
+		},
+		{
+			name:        "record still present",
+			parent:      parent(nameservers...),
+			ns1:         zone(),
+			ns2:         zone(www),
+			watch:       Watch{Zone: "example.test", Records: []Record{{Name: "www.example.test", Type: Type(dnsmessage.TypeA), Absent: true}}},
+			wantPending: []string{"ns2.example.net: still has www.example.test A"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := startServers(t, map[string]standIn{
+				"a.nic.test":      tt.parent,
+				"ns1.example.net": tt.ns1,
+				"ns2.example.net": tt.ns2,
+			}, standIn{delegations: map[string][]string{"test": {"a.nic.test"}}})
+
+			progress, err := c.Propagation(context.Background(), tt.watch)
+			if err != nil {
+				t.Fatalf("Propagation: %v", err)
+			}
+			if len(progress.Servers) != 3 {
+				t.Fatalf("got %d servers, want 3: %+v", len(progress.Servers), progress.Servers)
+			}
+			if got := progress.Servers[0]; got.Role != RoleParent || got.Nameserver != "a.nic.test" {
+				t.Errorf("first server = %+v, want the parent", got)
+			}
+
+			var pending []string
+			for _, s := range progress.Servers {
+				if !s.Done {
+					pending = append(pending, s.Nameserver+": "+s.Detail)
+				}
+			}
+			assertMessages(t, "pending", pending, tt.wantPending)
+			if progress.Done() != (len(tt.wantPending) == 0) {
+				t.Errorf("Done() = %v with %d pending", progress.Done(), progress.Pending())
+			}
+		})
+	}
+}
+
+func TestParseType(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"A", "aaaa", "MX", "TXT", "CAA", "TYPE99"} {
+		typ, err := ParseType(s)
+		if err != nil {
+			t.Errorf("ParseType(%q): %v", s, err)
+			continue
+		}
+		if got := typ.String(); got != strings.ToUpper(s) {
+			t.Errorf("ParseType(%q).String() = %q", s, got)
+		}
+	}
+	if _, err := ParseType("BOGUS"); err == nil {
+		t.Error("ParseType(BOGUS) succeeded, want error")
+	}
+}
+
+func TestValueMatches(t *testing.T) {
+	t.Parallel()
+
+	txt := &dnsmessage.TXTResource{TXT: []string{"v=spf1 include:Example.ch ", "-all"}}
+	for _, value := range []string{"v=spf1 include:Example.ch -all", `"v=spf1 include:Example.ch " "-all"`} {
+		if !valueMatches(txt, value) {
+			t.Errorf("valueMatches(TXT, %q) = false", value)
+		}
+	}
+	if valueMatches(txt, "v=spf1 include:example.ch -all") {
+		t.Error("TXT comparison must be case sensitive")
+	}
+}
+
+func TestNormalizeValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct{ a, b string }{
+		{"2001:DB8::0001", "2001:db8::1"},
+		{"10 Mail.Example.CH.", "10 mail.example.ch"},
+		{`"v=spf1 " "-all"`, `"v=spf1 -all"`},
+	}
+	for _, tt := range tests {
+		if normalizeValue(tt.a) != normalizeValue(tt.b) {
+			t.Errorf("normalizeValue(%q) = %q, normalizeValue(%q) = %q", tt.a, normalizeValue(tt.a), tt.b, normalizeValue(tt.b))
+		}
+	}
+}
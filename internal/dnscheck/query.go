@@ -0,0 +1,186 @@
+// Package dnscheck queries nameservers directly to verify that they serve a
+// zone correctly before a delegation is pointed at them.
+package dnscheck
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// DefaultTimeout bounds a single query to a nameserver.
+const DefaultTimeout = 5 * time.Second
+
+// Client sends DNS queries to specific servers, bypassing any recursive
+// resolver.
+type Client struct {
+	// Port is the port nameservers are queried on. Defaults to 53.
+	Port string
+	// Timeout bounds each query. Defaults to DefaultTimeout.
+	Timeout time.Duration
+	// Resolver looks up the addresses of nameserver host names. Nil uses
+	// the system resolver.
+	Resolver *net.Resolver
+}
+
+// NewResolver returns a resolver that sends its queries to server
+// ("host:port") instead of the system's configured resolvers.
+func NewResolver(server string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, server)
+		},
+	}
+}
+
+// Answer is the part of a DNS response the checks look at.
+type Answer struct {
+	Authoritative bool
+	RCode         dnsmessage.RCode
+	Answers       []dnsmessage.Resource
+	Authority     []dnsmessage.Resource
+}
+
+// LookupAddrs returns the addresses of a nameserver. IP literals are
+// returned as is.
+func (c *Client) LookupAddrs(ctx context.Context, host string) ([]string, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []string{host}, nil
+	}
+
+	r := c.Resolver
+	if r == nil {
+		r = net.DefaultResolver
+	}
+	addrs, err := r.LookupHost(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", host, err)
+	}
+	return addrs, nil
+}
+
+// Query sends a single non-recursive question for name and qtype to the
+// nameserver at addr over UDP, retrying over TCP if the answer is
+// truncated.
+func (c *Client) Query(ctx context.Context, addr, name string, qtype dnsmessage.Type) (*Answer, error) {
+	qname, err := dnsmessage.NewName(fqdn(name))
+	if err != nil {
+		return nil, fmt.Errorf("query %s: %w", name, err)
+	}
+
+	id := uint16(rand.IntN(1 << 16))
+	msg := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: id},
+		Questions: []dnsmessage.Question{{Name: qname, Type: qtype, Class: dnsmessage.ClassINET}},
+	}
+	packed, err := msg.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("query %s: %w", name, err)
+	}
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	server := net.JoinHostPort(addr, c.port())
+
+	resp, err := exchange(ctx, "udp", server, packed)
+	if err != nil {
+		return nil, fmt.Errorf("query %s %s at %s: %w", name, typeName(qtype), server, err)
+	}
+	if resp.Header.Truncated {
+		if resp, err = exchange(ctx, "tcp", server, packed); err != nil {
+			return nil, fmt.Errorf("query %s %s at %s: %w", name, typeName(qtype), server, err)
+		}
+	}
+	if resp.Header.ID != id {
+		return nil, fmt.Errorf("query %s %s at %s: mismatched response id", name, typeName(qtype), server)
+	}
+
+	return &Answer{
+		Authoritative: resp.Header.Authoritative,
+		RCode:         resp.Header.RCode,
+		Answers:       resp.Answers,
+		Authority:     resp.Authorities,
+	}, nil
+}
+
+func (c *Client) port() string {
+	if c.Port == "" {
+		return "53"
+	}
+	return c.Port
+}
+
+func exchange(ctx context.Context, network, server string, packed []byte) (*dnsmessage.Message, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	buf := make([]byte, 65535)
+	var n int
+	if network == "tcp" {
+		frame := append([]byte{byte(len(packed) >> 8), byte(len(packed))}, packed...)
+		if _, err := conn.Write(frame); err != nil {
+			return nil, err
+		}
+		var size [2]byte
+		if _, err := io.ReadFull(conn, size[:]); err != nil {
+			return nil, err
+		}
+		n = int(size[0])<<8 | int(size[1])
+		if _, err := io.ReadFull(conn, buf[:n]); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := conn.Write(packed); err != nil {
+			return nil, err
+		}
+		if n, err = conn.Read(buf); err != nil {
+			return nil, err
+		}
+	}
+
+	var msg dnsmessage.Message
+	if err := msg.Unpack(buf[:n]); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	return &msg, nil
+}
+
+// typeName returns the mnemonic of a record type, "SOA" rather than
+// "TypeSOA".
+func typeName(t dnsmessage.Type) string {
+	return strings.TrimPrefix(t.String(), "Type")
+}
+
+// fqdn returns name with a trailing dot.
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// normalizeName lowercases a host name and strips the trailing dot.
+func normalizeName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
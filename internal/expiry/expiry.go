@@ -0,0 +1,167 @@
+// Package expiry classifies domains by how soon they expire, using the
+// OK/WARNING/CRITICAL/UNKNOWN states of Nagios-compatible check plugins.
+package expiry
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+// Status is a check result. Its integer value is the plugin exit code.
+type Status int
+
+// Check states in order of severity.
+const (
+	OK       Status = 0
+	Warning  Status = 1
+	Critical Status = 2
+	Unknown  Status = 3
+)
+
+func (s Status) String() string {
+	switch s {
+	case OK:
+		return "OK"
+	case Warning:
+		return "WARNING"
+	case Critical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// severity orders states for the overall result. An unknown expiry date
+// is less pressing than a domain known to expire soon.
+func (s Status) severity() int {
+	switch s {
+	case OK:
+		return 0
+	case Unknown:
+		return 1
+	case Warning:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// MarshalText renders the status by name in JSON output.
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Entry is a domain that expires within the checked window, or whose expiry
+// date is unknown.
+type Entry struct {
+	Name string `json:"name"`
+	// ExpiresAt is zero when the API did not report an expiry date.
+	ExpiresAt time.Time `json:"expires_at"`
+	DaysLeft  int       `json:"days_left"`
+	Status    Status    `json:"status"`
+	// Covered is set for domains with a renewal warranty; they are listed
+	// but only raise the status once they have actually expired.
+	Covered bool `json:"covered"`
+}
+
+// Report is the outcome of a check over a set of domains.
+type Report struct {
+	Status  Status  `json:"status"`
+	Checked int     `json:"checked"`
+	Entries []Entry `json:"entries"`
+}
+
+// Thresholds configures when a domain becomes WARNING or CRITICAL.
+type Thresholds struct {
+	Warning  time.Duration
+	Critical time.Duration
+}
+
+// Check classifies domains expiring within t.Warning of now. Domains without
+// an expiry date are UNKNOWN. Entries are sorted by expiry date, soonest
+// first, after the unknown ones.
+func Check(domains []infomaniak.Domain, now time.Time, t Thresholds) Report {
+	r := Report{Status: OK, Checked: len(domains), Entries: []Entry{}}
+
+	for _, d := range domains {
+		if d.ExpiresAt == 0 {
+			r.Entries = append(r.Entries, Entry{Name: d.Name, Status: Unknown, Covered: d.Options.RenewalWarranty})
+			r.Status = worse(r.Status, Unknown)
+			continue
+		}
+
+		expires := time.Unix(d.ExpiresAt, 0)
+		left := expires.Sub(now)
+		if left > t.Warning {
+			continue
+		}
+
+		e := Entry{
+			Name:      d.Name,
+			ExpiresAt: expires.UTC(),
+			DaysLeft:  int(left.Hours() / 24),
+			Covered:   d.Options.RenewalWarranty,
+		}
+
+		switch {
+		case left <= 0:
+			e.Status = Critical
+		case e.Covered:
+			e.Status = OK
+		case left <= t.Critical:
+			e.Status = Critical
+		default:
+			e.Status = Warning
+		}
+
+		r.Entries = append(r.Entries, e)
+		r.Status = worse(r.Status, e.Status)
+	}
+
+	sort.SliceStable(r.Entries, func(i, j int) bool {
+		return r.Entries[i].ExpiresAt.Before(r.Entries[j].ExpiresAt)
+	})
+
+	return r
+}
+
+// worse returns the more severe of a and b.
+func worse(a, b Status) Status {
+	if b.severity() > a.severity() {
+		return b
+	}
+	return a
+}
+
+// Count returns the number of entries with the given status.
+func (r Report) Count(s Status) int {
+	n := 0
+	for _, e := range r.Entries {
+		if e.Status == s {
+			n++
+		}
+	}
+	return n
+}
+
+// Summary is the single status line of a check plugin, including perfdata.
+func (r Report) Summary(t Thresholds) string {
+	msg := fmt.Sprintf("%d domains checked, none unprotected expiring within %s", r.Checked, FormatDays(t.Warning))
+	if r.Status != OK {
+		msg = fmt.Sprintf("%d critical, %d warning of %d domains checked", r.Count(Critical), r.Count(Warning), r.Checked)
+	}
+	if n := r.Count(Unknown); n > 0 {
+		msg = fmt.Sprintf("%s, %d without expiry date", msg, n)
+	}
+
+	return fmt.Sprintf("DOMAINS %s - %s | critical=%d warning=%d unknown=%d covered=%d checked=%d",
+		r.Status, msg, r.Count(Critical), r.Count(Warning), r.Count(Unknown), r.Count(OK), r.Checked)
+}
+
+// FormatDays renders a duration as whole days, e.g. "60d".
+func FormatDays(d time.Duration) string {
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
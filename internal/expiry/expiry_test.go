@@ -0,0 +1,121 @@
+package expiry
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	at := func(d time.Duration) int64 { return now.Add(d).Unix() }
+	th := Thresholds{Warning: 60 * day, Critical: 14 * day}
+
+	tests := []struct {
+		name        string
+		domains     []infomaniak.Domain
+		wantStatus  Status
+		wantEntries []string
+	}{
+		{
+			name: "nothing expiring",
+			domains: []infomaniak.Domain{
+				{Name: "far.ch", ExpiresAt: at(200 * day)},
+			},
+			wantStatus:  OK,
+			wantEntries: nil,
+		},
+		{
+			name: "warning",
+			domains: []infomaniak.Domain{
+				{Name: "far.ch", ExpiresAt: at(200 * day)},
+				{Name: "soon.ch", ExpiresAt: at(30 * day)},
+			},
+			wantStatus:  Warning,
+			wantEntries: []string{"soon.ch:WARNING"},
+		},
+		{
+			name: "critical sorted by expiry",
+			domains: []infomaniak.Domain{
+				{Name: "soon.ch", ExpiresAt: at(30 * day)},
+				{Name: "urgent.ch", ExpiresAt: at(3 * day)},
+			},
+			wantStatus:  Critical,
+			wantEntries: []string{"urgent.ch:CRITICAL", "soon.ch:WARNING"},
+		},
+		{
+			name: "renewal warranty does not alert",
+			domains: []infomaniak.Domain{
+				{Name: "covered.ch", ExpiresAt: at(3 * day), Options: infomaniak.DomainOptions{RenewalWarranty: true}},
+			},
+			wantStatus:  OK,
+			wantEntries: []string{"covered.ch:OK"},
+		},
+		{
+			name: "expired is critical even with warranty",
+			domains: []infomaniak.Domain{
+				{Name: "gone.ch", ExpiresAt: at(-2 * day), Options: infomaniak.DomainOptions{RenewalWarranty: true}},
+			},
+			wantStatus:  Critical,
+			wantEntries: []string{"gone.ch:CRITICAL"},
+		},
+		{
+			name: "missing expiry date is unknown",
+			domains: []infomaniak.Domain{
+				{Name: "far.ch", ExpiresAt: at(200 * day)},
+				{Name: "nodate.ch"},
+			},
+			wantStatus:  Unknown,
+			wantEntries: []string{"nodate.ch:UNKNOWN"},
+		},
+		{
+			name: "critical outranks unknown",
+			domains: []infomaniak.Domain{
+				{Name: "nodate.ch"},
+				{Name: "urgent.ch", ExpiresAt: at(3 * day)},
+			},
+			wantStatus:  Critical,
+			wantEntries: []string{"nodate.ch:UNKNOWN", "urgent.ch:CRITICAL"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := Check(tt.domains, now, th)
+			if r.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", r.Status, tt.wantStatus)
+			}
+
+			var got []string
+			for _, e := range r.Entries {
+				got = append(got, e.Name+":"+e.Status.String())
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantEntries, ",") {
+				t.Errorf("entries = %v, want %v", got, tt.wantEntries)
+			}
+		})
+	}
+}
+
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	th := Thresholds{Warning: 60 * 24 * time.Hour, Critical: 14 * 24 * time.Hour}
+	r := Check([]infomaniak.Domain{
+		{Name: "a.ch", ExpiresAt: now.Add(5 * 24 * time.Hour).Unix()},
+		{Name: "b.ch", ExpiresAt: now.Add(300 * 24 * time.Hour).Unix()},
+	}, now, th)
+
+	want := "DOMAINS CRITICAL - 1 critical, 0 warning of 2 domains checked | critical=1 warning=0 unknown=0 covered=0 checked=2"
+	if got := r.Summary(th); got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,260 @@
+// Package exporter polls the Infomaniak API in the background and serves
+// the results as Prometheus metrics.
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+// Source is the subset of infomaniak.Client the exporter polls.
+type Source interface {
+	ListDomains(ctx context.Context) ([]infomaniak.Domain, error)
+	ShowDomain(ctx context.Context, domain string) (*infomaniak.Domain, error)
+	ListRecords(ctx context.Context, domain string) ([]infomaniak.Record, error)
+}
+
+// Config controls what is polled and how often.
+type Config struct {
+	Interval time.Duration
+	// Records also polls the DNS record count of each domain's zone.
+	Records bool
+}
+
+// Exporter keeps the metrics of the latest poll and serves them over HTTP.
+type Exporter struct {
+	src Source
+	cfg Config
+
+	mu       sync.RWMutex
+	domains  []domainMetrics
+	errors   map[string]float64
+	duration time.Duration
+	success  bool
+	lastPoll time.Time
+}
+
+type domainMetrics struct {
+	name        string
+	expiresAt   int64
+	dnssec      bool
+	privacy     bool
+	warranty    bool
+	nameservers int
+	hasDetails  bool
+	records     int
+	hasRecords  bool
+}
+
+// New returns an Exporter polling src.
+func New(src Source, cfg Config) *Exporter {
+	return &Exporter{
+		src:    src,
+		cfg:    cfg,
+		errors: make(map[string]float64),
+	}
+}
+
+// Run polls immediately and then every cfg.Interval until ctx is done.
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		e.Poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches all domains once and replaces the served metrics. Domains
+// whose details fail to load keep their values from the list response.
+func (e *Exporter) Poll(ctx context.Context) {
+	start := time.Now()
+
+	domains, err := e.src.ListDomains(ctx)
+	if err != nil {
+		slog.Error("poll domains", "error", err)
+		e.finish(nil, start, map[string]float64{"list_domains": 1})
+		return
+	}
+
+	failures := make(map[string]float64)
+	metrics := make([]domainMetrics, 0, len(domains))
+	for _, d := range domains {
+		detail, err := e.src.ShowDomain(ctx, d.Name)
+		if err != nil {
+			slog.Warn("poll domain details", "domain", d.Name, "error", err)
+			failures["show_domain"]++
+		} else {
+			d = *detail
+		}
+
+		m := domainMetrics{
+			name:        d.Name,
+			expiresAt:   d.ExpiresAt,
+			dnssec:      d.Options.DNSSEC,
+			privacy:     d.Options.DomainPrivacy,
+			warranty:    d.Options.RenewalWarranty,
+			nameservers: len(d.Nameservers),
+			hasDetails:  err == nil,
+		}
+
+		if e.cfg.Records {
+			if records, err := e.src.ListRecords(ctx, d.Name); err != nil {
+				slog.Warn("poll dns records", "domain", d.Name, "error", err)
+				failures["list_records"]++
+			} else {
+				m.records, m.hasRecords = len(records), true
+			}
+		}
+
+		metrics = append(metrics, m)
+	}
+
+	e.finish(metrics, start, failures)
+}
+
+func (e *Exporter) finish(metrics []domainMetrics, start time.Time, failures map[string]float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if metrics != nil {
+		e.domains = metrics
+	}
+	for endpoint, n := range failures {
+		e.errors[endpoint] += n
+	}
+	e.duration = time.Since(start)
+	e.success = metrics != nil
+	e.lastPoll = start
+}
+
+// Handler serves /metrics and a minimal landing page.
+func (e *Exporter) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		e.WriteMetrics(w)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, `<html><body><h1>Infomaniak exporter</h1><p><a href="/metrics">Metrics</a></p></body></html>`)
+	})
+	return mux
+}
+
+// WriteMetrics writes the current metrics in the Prometheus text format.
+func (e *Exporter) WriteMetrics(w io.Writer) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	perDomain := func(name, help string, value func(domainMetrics) (float64, bool)) {
+		var samples []sample
+		for _, d := range e.domains {
+			if v, ok := value(d); ok {
+				samples = append(samples, sample{labels: []string{"domain", d.name}, value: v})
+			}
+		}
+		writeFamily(w, name, help, "gauge", samples)
+	}
+
+	// Domains without an expiry date are left out rather than exported as
+	// 1970, which would set off every expiry alert.
+	perDomain("infomaniak_domain_expiry_timestamp_seconds", "Unix time at which the domain registration expires.",
+		func(d domainMetrics) (float64, bool) { return float64(d.expiresAt), d.expiresAt != 0 })
+	perDomain("infomaniak_domain_dnssec_enabled", "Whether DNSSEC is enabled for the domain.",
+		func(d domainMetrics) (float64, bool) { return boolValue(d.dnssec), true })
+	perDomain("infomaniak_domain_privacy_enabled", "Whether WHOIS privacy is enabled for the domain.",
+		func(d domainMetrics) (float64, bool) { return boolValue(d.privacy), true })
+	perDomain("infomaniak_domain_renewal_warranty_enabled", "Whether the renewal warranty is enabled for the domain.",
+		func(d domainMetrics) (float64, bool) { return boolValue(d.warranty), true })
+	perDomain("infomaniak_domain_nameservers", "Number of nameservers configured for the domain.",
+		func(d domainMetrics) (float64, bool) { return float64(d.nameservers), d.hasDetails })
+	if e.cfg.Records {
+		perDomain("infomaniak_dns_records", "Number of DNS records in the domain's zone.",
+			func(d domainMetrics) (float64, bool) { return float64(d.records), d.hasRecords })
+	}
+
+	writeFamily(w, "infomaniak_domains", "Number of domains in the account.", "gauge",
+		[]sample{{value: float64(len(e.domains))}})
+
+	var errSamples []sample
+	for _, endpoint := range []string{"list_domains", "show_domain", "list_records"} {
+		errSamples = append(errSamples, sample{labels: []string{"endpoint", endpoint}, value: e.errors[endpoint]})
+	}
+	writeFamily(w, "infomaniak_scrape_errors_total", "API calls that failed while polling, by endpoint.", "counter", errSamples)
+
+	writeFamily(w, "infomaniak_scrape_duration_seconds", "Duration of the last API poll.", "gauge",
+		[]sample{{value: e.duration.Seconds()}})
+	writeFamily(w, "infomaniak_scrape_success", "Whether the last API poll could list the domains.", "gauge",
+		[]sample{{value: boolValue(e.success)}})
+
+	var last float64
+	if !e.lastPoll.IsZero() {
+		last = float64(e.lastPoll.Unix())
+	}
+	writeFamily(w, "infomaniak_last_scrape_timestamp_seconds", "Unix time of the last API poll.", "gauge",
+		[]sample{{value: last}})
+}
+
+type sample struct {
+	// labels holds alternating label names and values.
+	labels []string
+	value  float64
+}
+
+func writeFamily(w io.Writer, name, help, typ string, samples []sample) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+
+	sort.SliceStable(samples, func(i, j int) bool {
+		return strings.Join(samples[i].labels, "\x00") < strings.Join(samples[j].labels, "\x00")
+	})
+	for _, s := range samples {
+		fmt.Fprintf(w, "%s%s %s\n", name, formatLabels(s.labels), strconv.FormatFloat(s.value, 'f', -1, 64))
+	}
+}
+
+func formatLabels(kv []string) string {
+	if len(kv) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i+1 < len(kv); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `%s="%s"`, kv[i], labelEscaper.Replace(kv[i+1]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// labelEscaper escapes label values as required by the text format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
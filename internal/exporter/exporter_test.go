@@ -0,0 +1,163 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+type fakeSource struct {
+	domains    []infomaniak.Domain
+	listErr    error
+	showErr    map[string]error
+	records    map[string][]infomaniak.Record
+	recordsErr error
+}
+
+func (f *fakeSource) ListDomains(context.Context) ([]infomaniak.Domain, error) {
+	return f.domains, f.listErr
+}
+
+func (f *fakeSource) ShowDomain(_ context.Context, name string) (*infomaniak.Domain, error) {
+	if err := f.showErr[name]; err != nil {
+		return nil, err
+	}
+	for _, d := range f.domains {
+		if d.Name == name {
+			d.Nameservers = []string{"ns1.example.net", "ns2.example.net"}
+			return &d, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (f *fakeSource) ListRecords(_ context.Context, name string) ([]infomaniak.Record, error) {
+	return f.records[name], f.recordsErr
+}
+
+func scrape(t *testing.T, e *Exporter) string {
+	t.Helper()
+
+	srv := httptest.NewServer(e.Handler())
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("get metrics: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("content type = %q", ct)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestExporterMetrics(t *testing.T) {
+	t.Parallel()
+
+	src := &fakeSource{
+		domains: []infomaniak.Domain{
+			{Name: "b.ch", ExpiresAt: 1777500000, Options: infomaniak.DomainOptions{DNSSEC: true}},
+			{Name: "a.ch", ExpiresAt: 1800000000, Options: infomaniak.DomainOptions{DomainPrivacy: true}},
+		},
+		showErr: map[string]error{"a.ch": errors.New("timeout")},
+		records: map[string][]infomaniak.Record{"b.ch": {{ID: 1}, {ID: 2}, {ID: 3}}},
+	}
+
+	e := New(src, Config{Interval: time.Minute, Records: true})
+	e.Poll(context.Background())
+	out := scrape(t, e)
+
+	for _, want := range []string{
+		"# TYPE infomaniak_domain_expiry_timestamp_seconds gauge\n",
+		`infomaniak_domain_expiry_timestamp_seconds{domain="a.ch"} 1800000000` + "\n",
+		`infomaniak_domain_expiry_timestamp_seconds{domain="b.ch"} 1777500000` + "\n",
+		`infomaniak_domain_dnssec_enabled{domain="b.ch"} 1` + "\n",
+		`infomaniak_domain_privacy_enabled{domain="a.ch"} 1` + "\n",
+		`infomaniak_domain_nameservers{domain="b.ch"} 2` + "\n",
+		`infomaniak_dns_records{domain="b.ch"} 3` + "\n",
+		"infomaniak_domains 2\n",
+		`infomaniak_scrape_errors_total{endpoint="show_domain"} 1` + "\n",
+		`infomaniak_scrape_errors_total{endpoint="list_domains"} 0` + "\n",
+		"infomaniak_scrape_success 1\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `infomaniak_domain_nameservers{domain="a.ch"}`) {
+		t.Error("nameserver count exported for a domain whose details failed")
+	}
+}
+
+func TestExporterListFailureKeepsLastMetrics(t *testing.T) {
+	t.Parallel()
+
+	src := &fakeSource{domains: []infomaniak.Domain{{Name: "a.ch", ExpiresAt: 1800000000}}}
+	e := New(src, Config{Interval: time.Minute})
+	e.Poll(context.Background())
+
+	src.listErr = errors.New("api down")
+	e.Poll(context.Background())
+	e.Poll(context.Background())
+	out := scrape(t, e)
+
+	for _, want := range []string{
+		`infomaniak_domain_expiry_timestamp_seconds{domain="a.ch"} 1800000000` + "\n",
+		`infomaniak_scrape_errors_total{endpoint="list_domains"} 2` + "\n",
+		"infomaniak_scrape_success 0\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "infomaniak_dns_records") {
+		t.Error("dns record metrics exported although disabled")
+	}
+}
+
+func TestExporterSkipsMissingExpiry(t *testing.T) {
+	t.Parallel()
+
+	src := &fakeSource{domains: []infomaniak.Domain{
+		{Name: "a.ch", ExpiresAt: 1800000000},
+		{Name: "nodate.ch"},
+	}}
+	e := New(src, Config{Interval: time.Minute})
+	e.Poll(context.Background())
+	out := scrape(t, e)
+
+	if strings.Contains(out, `infomaniak_domain_expiry_timestamp_seconds{domain="nodate.ch"}`) {
+		t.Errorf("expiry exported for a domain without an expiry date:\n%s", out)
+	}
+	for _, want := range []string{
+		`infomaniak_domain_expiry_timestamp_seconds{domain="a.ch"} 1800000000` + "\n",
+		`infomaniak_domain_dnssec_enabled{domain="nodate.ch"} 0` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatLabelsEscapes(t *testing.T) {
+	t.Parallel()
+
+	got := formatLabels([]string{"domain", "we\"ird\\name\n"})
+	want := `{domain="we\"ird\\name\n"}`
+	if got != want {
+		t.Errorf("labels = %s, want %s", got, want)
+	}
+}
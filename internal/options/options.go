@@ -0,0 +1,112 @@
+// Package options computes which domain options have to change to bring a
+// set of domains in line with a policy such as "privacy on for all .com".
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+// Option names a toggleable domain option.
+type Option string
+
+// Options in the order they are reported.
+const (
+	Privacy         Option = "privacy"
+	Anycast         Option = "anycast"
+	RenewalWarranty Option = "renewal-warranty"
+)
+
+// Policy holds the desired state of each option. Nil options are left as
+// they are.
+type Policy struct {
+	Privacy         *bool
+	Anycast         *bool
+	RenewalWarranty *bool
+}
+
+// Empty reports whether the policy does not set any option.
+func (p Policy) Empty() bool {
+	return p.Privacy == nil && p.Anycast == nil && p.RenewalWarranty == nil
+}
+
+// Change is an option whose current value differs from the policy.
+type Change struct {
+	Option Option `json:"option"`
+	From   bool   `json:"from"`
+	To     bool   `json:"to"`
+}
+
+// DomainChanges lists the changes required for one domain.
+type DomainChanges struct {
+	Domain  string   `json:"domain"`
+	Changes []Change `json:"changes"`
+}
+
+// Input builds the API request that applies the changes.
+func (d DomainChanges) Input() infomaniak.OptionsInput {
+	var input infomaniak.OptionsInput
+	for _, c := range d.Changes {
+		to := c.To
+		switch c.Option {
+		case Privacy:
+			input.DomainPrivacy = &to
+		case Anycast:
+			input.DNSAnycast = &to
+		case RenewalWarranty:
+			input.RenewalWarranty = &to
+		}
+	}
+	return input
+}
+
+// Diff returns the changes needed for each domain, in input order. Domains
+// that already comply with the policy are omitted.
+func Diff(domains []infomaniak.Domain, p Policy) []DomainChanges {
+	var out []DomainChanges
+	for _, d := range domains {
+		fields := []struct {
+			option  Option
+			want    *bool
+			current bool
+		}{
+			{Privacy, p.Privacy, d.Options.DomainPrivacy},
+			{Anycast, p.Anycast, d.Options.DNSAnycast},
+			{RenewalWarranty, p.RenewalWarranty, d.Options.RenewalWarranty},
+		}
+
+		var changes []Change
+		for _, f := range fields {
+			if f.want != nil && *f.want != f.current {
+				changes = append(changes, Change{Option: f.option, From: f.current, To: *f.want})
+			}
+		}
+		if len(changes) > 0 {
+			out = append(out, DomainChanges{Domain: d.Name, Changes: changes})
+		}
+	}
+	return out
+}
+
+// ParseState parses an on/off switch value. true/false and yes/no are
+// accepted as well.
+func ParseState(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "on", "true", "yes":
+		return true, nil
+	case "off", "false", "no":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid state %q: use on or off", s)
+	}
+}
+
+// FormatState renders a boolean option as on or off.
+func FormatState(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,105 @@
+package options
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	on, off := true, false
+	domains := []infomaniak.Domain{
+		{Name: "a.com", Options: infomaniak.DomainOptions{DomainPrivacy: true}},
+		{Name: "b.com", Options: infomaniak.DomainOptions{DNSAnycast: true}},
+		{Name: "c.com"},
+	}
+
+	tests := []struct {
+		name   string
+		policy Policy
+		want   []DomainChanges
+	}{
+		{
+			name:   "empty policy",
+			policy: Policy{},
+			want:   nil,
+		},
+		{
+			name:   "privacy on skips compliant domains",
+			policy: Policy{Privacy: &on},
+			want: []DomainChanges{
+				{Domain: "b.com", Changes: []Change{{Option: Privacy, From: false, To: true}}},
+				{Domain: "c.com", Changes: []Change{{Option: Privacy, From: false, To: true}}},
+			},
+		},
+		{
+			name:   "several options",
+			policy: Policy{Privacy: &off, Anycast: &off},
+			want: []DomainChanges{
+				{Domain: "a.com", Changes: []Change{{Option: Privacy, From: true, To: false}}},
+				{Domain: "b.com", Changes: []Change{{Option: Anycast, From: true, To: false}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Diff(domains, tt.policy)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDomainChangesInput(t *testing.T) {
+	t.Parallel()
+
+	d := DomainChanges{Domain: "a.com", Changes: []Change{
+		{Option: Privacy, To: true},
+		{Option: RenewalWarranty, From: true, To: false},
+	}}
+
+	input := d.Input()
+	if input.DomainPrivacy == nil || !*input.DomainPrivacy {
+		t.Errorf("DomainPrivacy = %v, want true", input.DomainPrivacy)
+	}
+	if input.RenewalWarranty == nil || *input.RenewalWarranty {
+		t.Errorf("RenewalWarranty = %v, want false", input.RenewalWarranty)
+	}
+	if input.DNSAnycast != nil {
+		t.Errorf("DNSAnycast = %v, want nil", *input.DNSAnycast)
+	}
+}
+
+func TestParseState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    bool
+		wantErr bool
+	}{
+		{in: "on", want: true},
+		{in: "OFF"},
+		{in: "true", want: true},
+		{in: "no"},
+		{in: "maybe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseState(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseState(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseState(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NameserverFile is the name of the nameserver history file in Dir.
+const NameserverFile = "nameservers.json"
+
+// SavedNameservers is the nameserver set a domain had before a change.
+type SavedNameservers struct {
+	Nameservers []string  `json:"nameservers"`
+	SavedAt     time.Time `json:"saved_at"`
+}
+
+// Nameservers records, per domain, the nameserver set that was replaced by
+// the last change so that it can be restored. It is safe for concurrent use.
+type Nameservers struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewNameservers returns a store backed by the JSON file at path.
+func NewNameservers(path string) *Nameservers {
+	return &Nameservers{path: path}
+}
+
+// DefaultNameservers returns the store in Dir.
+func DefaultNameservers() (*Nameservers, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return NewNameservers(filepath.Join(dir, NameserverFile)), nil
+}
+
+// Save records nameservers as the set domain had before a change,
+// replacing any earlier record.
+func (s *Nameservers) Save(domain string, nameservers []string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved, err := s.load()
+	if err != nil {
+		return err
+	}
+	saved[key(domain)] = SavedNameservers{
+		Nameservers: append([]string(nil), nameservers...),
+		SavedAt:     at.UTC(),
+	}
+	return s.save(saved)
+}
+
+// Previous returns the nameservers recorded for domain and whether there
+// was a record.
+func (s *Nameservers) Previous(domain string) (SavedNameservers, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved, err := s.load()
+	if err != nil {
+		return SavedNameservers{}, false, err
+	}
+	prev, ok := saved[key(domain)]
+	return prev, ok, nil
+}
+
+// Forget removes the record for domain, e.g. once it has been restored, so
+// that a rollback is not undone by the next one.
+func (s *Nameservers) Forget(domain string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := saved[key(domain)]; !ok {
+		return nil
+	}
+	delete(saved, key(domain))
+	return s.save(saved)
+}
+
+func (s *Nameservers) load() (map[string]SavedNameservers, error) {
+	saved := make(map[string]SavedNameservers)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return saved, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read nameserver history: %w", err)
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("parse nameserver history %s: %w", s.path, err)
+	}
+	return saved, nil
+}
+
+// save writes the history to a temporary file first so that an interrupted
+// write never leaves a truncated file behind.
+func (s *Nameservers) save(saved map[string]SavedNameservers) error {
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode nameserver history: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("create state directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".nameservers-*")
+	if err != nil {
+		return fmt.Errorf("write nameserver history: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write nameserver history: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write nameserver history: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("write nameserver history: %w", err)
+	}
+	return nil
+}
+
+func key(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(domain), ".")
+}
@@ -0,0 +1,23 @@
+// Package state keeps data the CLI records about past operations, such as
+// the nameservers a domain had before they were changed.
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Dir returns the directory state files are kept in: $XDG_STATE_HOME/infomaniak,
+// or ~/.local/state/infomaniak when XDG_STATE_HOME is not set.
+func Dir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "infomaniak"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("find home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "state", "infomaniak"), nil
+}
@@ -0,0 +1,107 @@
+package state
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDir(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/var/lib/state")
+	dir, err := Dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join("/var/lib/state", "infomaniak"); dir != want {
+		t.Errorf("Dir() = %q, want %q", dir, want)
+	}
+
+	t.Setenv("XDG_STATE_HOME", "relative")
+	t.Setenv("HOME", "/home/user")
+	dir, err = Dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join("/home/user", ".local", "state", "infomaniak"); dir != want {
+		t.Errorf("Dir() = %q, want %q", dir, want)
+	}
+}
+
+func TestNameservers(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state", NameserverFile)
+	s := NewNameservers(path)
+
+	if _, ok, err := s.Previous("example.ch"); err != nil || ok {
+		t.Fatalf("Previous on empty store = %v, %v", ok, err)
+	}
+
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := s.Save("Example.CH.", []string{"ns1.old.ch", "ns2.old.ch"}, at); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := s.Save("example.com", []string{"ns1.other.net"}, at); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	// A fresh store reads what the first one wrote.
+	prev, ok, err := NewNameservers(path).Previous("example.ch")
+	if err != nil || !ok {
+		t.Fatalf("Previous = %v, %v", ok, err)
+	}
+	if !slices.Equal(prev.Nameservers, []string{"ns1.old.ch", "ns2.old.ch"}) || !prev.SavedAt.Equal(at) {
+		t.Errorf("Previous = %+v", prev)
+	}
+
+	if err := s.Save("example.ch", []string{"ns1.new.ch"}, at.Add(time.Hour)); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	prev, _, _ = s.Previous("example.ch")
+	if !slices.Equal(prev.Nameservers, []string{"ns1.new.ch"}) {
+		t.Errorf("Previous after overwrite = %+v", prev)
+	}
+	if prev, _, _ := s.Previous("example.com"); !slices.Equal(prev.Nameservers, []string{"ns1.other.net"}) {
+		t.Errorf("unrelated domain lost: %+v", prev)
+	}
+
+	if err := s.Forget("EXAMPLE.ch"); err != nil {
+		t.Fatalf("forget: %v", err)
+	}
+	if _, ok, err := NewNameservers(path).Previous("example.ch"); err != nil || ok {
+		t.Errorf("Previous after Forget = %v, %v; want no record", ok, err)
+	}
+	if _, ok, _ := s.Previous("example.com"); !ok {
+		t.Error("Forget removed an unrelated domain")
+	}
+	if err := s.Forget("example.ch"); err != nil {
+		t.Errorf("second forget: %v", err)
+	}
+}
+
+func TestNameserversConcurrentSave(t *testing.T) {
+	t.Parallel()
+
+	s := NewNameservers(filepath.Join(t.TempDir(), NameserverFile))
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.Save(fmt.Sprintf("example%d.ch", i), []string{"ns1.example.net"}, time.Now()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range 20 {
+		if _, ok, err := s.Previous(fmt.Sprintf("example%d.ch", i)); err != nil || !ok {
+			t.Errorf("example%d.ch: ok=%v err=%v", i, ok, err)
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package zone
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+// nameTargets lists record types whose target is a domain name and must be
+// made absolute in a master file.
+var nameTargets = map[string]bool{
+	"CNAME": true,
+	"DNAME": true,
+	"MX":    true,
+	"NS":    true,
+	"PTR":   true,
+	"SRV":   true,
+}
+
+// WriteBIND renders records as an RFC 1035 master file for domain. Records
+// are sorted so that repeated exports of an unchanged zone are identical.
+func WriteBIND(w io.Writer, domain string, records []infomaniak.Record) error {
+	origin := fqdn(domain)
+
+	sorted := make([]infomaniak.Record, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if sa, sb := normalizeSource(a.Source), normalizeSource(b.Source); sa != sb {
+			return sa < sb
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.Target < b.Target
+	})
+
+	fmt.Fprintf(w, "$ORIGIN %s\n", origin)
+	fmt.Fprintf(w, "$TTL %d\n\n", DefaultTTL)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	for _, r := range sorted {
+		owner := normalizeSource(r.Source)
+		if owner == "." {
+			owner = "@"
+		}
+		typ := strings.ToUpper(r.Type)
+		fmt.Fprintf(tw, "%s\t%d\tIN\t%s\t%s\n", owner, r.TTL, typ, bindRData(typ, r))
+	}
+
+	return tw.Flush()
+}
+
+func bindRData(typ string, r infomaniak.Record) string {
+	target := r.Target
+	if nameTargets[typ] {
+		target = strings.TrimSuffix(target, ".") + "."
+	}
+
+	switch typ {
+	case "MX":
+		return fmt.Sprintf("%d %s", r.Priority, target)
+	case "SRV":
+		return fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, target)
+	case "TXT", "SPF":
+		return quoteTXT(r.Target)
+	default:
+		return target
+	}
+}
+
+// quoteTXT quotes a TXT value, splitting it into 255-byte character strings.
+func quoteTXT(s string) string {
+	var parts []string
+	for len(s) > 255 {
+		parts = append(parts, s[:255])
+		s = s[255:]
+	}
+	parts = append(parts, s)
+
+	for i, p := range parts {
+		p = strings.ReplaceAll(p, `\`, `\\`)
+		p = strings.ReplaceAll(p, `"`, `\"`)
+		parts[i] = `"` + p + `"`
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseBIND reads an RFC 1035 master file and returns the records it
+// contains relative to domain. SOA records and NS records at the apex are
+// skipped since they are managed by the DNS provider.
+func ParseBIND(r io.Reader, domain string) ([]infomaniak.RecordInput, error) {
+	p := &bindParser{
+		origin: fqdn(domain),
+		apex:   fqdn(domain),
+		ttl:    DefaultTTL,
+	}
+
+	entries, err := readEntries(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []infomaniak.RecordInput
+	for _, e := range entries {
+		in, ok, err := p.parse(e)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", e.line, err)
+		}
+		if ok {
+			out = append(out, in)
+		}
+	}
+
+	return out, nil
+}
+
+// entry is one logical line of a master file, with parentheses joined.
+type entry struct {
+	line     int
+	indented bool
+	tokens   []string
+	quoted   []bool
+}
+
+type bindParser struct {
+	origin    string
+	apex      string
+	ttl       int
+	lastOwner string
+}
+
+func (p *bindParser) parse(e entry) (infomaniak.RecordInput, bool, error) {
+	toks := e.tokens
+
+	switch strings.ToUpper(toks[0]) {
+	case "$ORIGIN":
+		if len(toks) < 2 {
+			return infomaniak.RecordInput{}, false, fmt.Errorf("$ORIGIN requires a name")
+		}
+		p.origin = absoluteName(toks[1], p.origin) + "."
+		return infomaniak.RecordInput{}, false, nil
+	case "$TTL":
+		if len(toks) < 2 {
+			return infomaniak.RecordInput{}, false, fmt.Errorf("$TTL requires a value")
+		}
+		ttl, err := parseTTL(toks[1])
+		if err != nil {
+			return infomaniak.RecordInput{}, false, err
+		}
+		p.ttl = ttl
+		return infomaniak.RecordInput{}, false, nil
+	case "$INCLUDE", "$GENERATE":
+		return infomaniak.RecordInput{}, false, fmt.Errorf("%s is not supported", toks[0])
+	}
+
+	owner := p.lastOwner
+	i := 0
+	if !e.indented {
+		owner = absoluteName(toks[0], p.origin)
+		i = 1
+	}
+	if owner == "" {
+		return infomaniak.RecordInput{}, false, fmt.Errorf("record without owner name")
+	}
+	p.lastOwner = owner
+
+	ttl := p.ttl
+	typ := ""
+	for ; i < len(toks); i++ {
+		t := strings.ToUpper(toks[i])
+		if t == "IN" || t == "CH" || t == "HS" {
+			continue
+		}
+		if v, err := parseTTL(t); err == nil {
+			ttl = v
+			continue
+		}
+		typ = t
+		i++
+		break
+	}
+	if typ == "" {
+		return infomaniak.RecordInput{}, false, fmt.Errorf("missing record type")
+	}
+	rdata := toks[i:]
+	quoted := e.quoted[i:]
+	if len(rdata) == 0 {
+		return infomaniak.RecordInput{}, false, fmt.Errorf("%s record without data", typ)
+	}
+
+	if typ == "SOA" || (typ == "NS" && owner == strings.TrimSuffix(p.apex, ".")) {
+		return infomaniak.RecordInput{}, false, nil
+	}
+
+	source, err := relativeName(owner, p.apex)
+	if err != nil {
+		return infomaniak.RecordInput{}, false, err
+	}
+
+	in := infomaniak.RecordInput{Source: source, Type: typ, TTL: ttl}
+
+	switch typ {
+	case "MX":
+		if len(rdata) != 2 {
+			return in, false, fmt.Errorf("MX record needs priority and exchange")
+		}
+		if in.Priority, err = strconv.Atoi(rdata[0]); err != nil {
+			return in, false, fmt.Errorf("invalid MX priority %q", rdata[0])
+		}
+		in.Target = absoluteName(rdata[1], p.origin)
+	case "SRV":
+		if len(rdata) != 4 {
+			return in, false, fmt.Errorf("SRV record needs priority, weight, port and target")
+		}
+		nums := []*int{&in.Priority, &in.Weight, &in.Port}
+		for j, dst := range nums {
+			if *dst, err = strconv.Atoi(rdata[j]); err != nil {
+				return in, false, fmt.Errorf("invalid SRV field %q", rdata[j])
+			}
+		}
+		in.Target = absoluteName(rdata[3], p.origin)
+	case "TXT", "SPF":
+		in.Target = strings.Join(rdata, "")
+	default:
+		if nameTargets[typ] {
+			in.Target = absoluteName(rdata[0], p.origin)
+			break
+		}
+		parts := make([]string, len(rdata))
+		for j, tok := range rdata {
+			if quoted[j] {
+				tok = `"` + tok + `"`
+			}
+			parts[j] = tok
+		}
+		in.Target = strings.Join(parts, " ")
+	}
+
+	return normalize(in), true, nil
+}
+
+// readEntries tokenizes a master file into logical entries, handling
+// comments, quoted strings and parenthesized continuation lines.
+func readEntries(r io.Reader) ([]entry, error) {
+	var (
+		entries []entry
+		cur     entry
+		depth   int
+	)
+
+	sc := bufio.NewScanner(r)
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		line := sc.Text()
+
+		if depth == 0 {
+			cur = entry{line: lineNo, indented: line != "" && (line[0] == ' ' || line[0] == '\t')}
+		}
+
+		for i := 0; i < len(line); i++ {
+			c := line[i]
+			switch {
+			case c == ';':
+				i = len(line)
+			case c == ' ' || c == '\t':
+			case c == '(':
+				depth++
+			case c == ')':
+				if depth == 0 {
+					return nil, fmt.Errorf("line %d: unbalanced parenthesis", lineNo)
+				}
+				depth--
+			case c == '"':
+				var sb strings.Builder
+				i++
+				for ; i < len(line) && line[i] != '"'; i++ {
+					if line[i] == '\\' && i+1 < len(line) {
+						i++
+					}
+					sb.WriteByte(line[i])
+				}
+				if i >= len(line) {
+					return nil, fmt.Errorf("line %d: unterminated string", lineNo)
+				}
+				cur.tokens = append(cur.tokens, sb.String())
+				cur.quoted = append(cur.quoted, true)
+			default:
+				start := i
+				for i < len(line) && !strings.ContainsRune(" \t;()\"", rune(line[i])) {
+					i++
+				}
+				cur.tokens = append(cur.tokens, line[start:i])
+				cur.quoted = append(cur.quoted, false)
+				i--
+			}
+		}
+
+		if depth == 0 && len(cur.tokens) > 0 {
+			entries = append(entries, cur)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read zone file: %w", err)
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parenthesis at end of file")
+	}
+
+	return entries, nil
+}
+
+// parseTTL parses a TTL in seconds or with BIND unit suffixes like 1h30m.
+func parseTTL(s string) (int, error) {
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return n, nil
+	}
+
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	total, num := 0, ""
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20
+		switch {
+		case s[i] >= '0' && s[i] <= '9':
+			num += string(s[i])
+		case units[c] > 0 && num != "":
+			n, _ := strconv.Atoi(num)
+			total += n * units[c]
+			num = ""
+		default:
+			return 0, fmt.Errorf("invalid ttl %q", s)
+		}
+	}
+	if num != "" || s == "" {
+		return 0, fmt.Errorf("invalid ttl %q", s)
+	}
+	return total, nil
+}
+
+func fqdn(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".") + "."
+}
+
+// absoluteName resolves name against origin and returns it without the
+// trailing dot, which is how the API stores record targets.
+func absoluteName(name, origin string) string {
+	switch {
+	case name == "@":
+		return strings.TrimSuffix(origin, ".")
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	default:
+		return name + "." + strings.TrimSuffix(origin, ".")
+	}
+}
+
+// relativeName converts an absolute owner name to a source relative to apex.
+func relativeName(owner, apex string) (string, error) {
+	owner = strings.ToLower(owner)
+	apex = strings.TrimSuffix(apex, ".")
+	if owner == apex {
+		return ".", nil
+	}
+	if !strings.HasSuffix(owner, "."+apex) {
+		return "", fmt.Errorf("name %q is outside of zone %s", owner, apex)
+	}
+	return strings.TrimSuffix(owner, "."+apex), nil
+}
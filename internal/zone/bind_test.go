@@ -0,0 +1,139 @@
+package zone
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+const sampleZone = `$ORIGIN example.ch.
+$TTL 1h
+@   IN SOA ns1.example.net. hostmaster.example.ch. (
+        2024010101 ; serial
+        7200 3600 1209600 300 )
+@       IN  NS    ns1.example.net.
+@       300 IN A  192.0.2.1
+www         CNAME @
+        IN  AAAA  2001:db8::1 ; inherits owner www
+@           MX    10 mx1
+@           MX    20 mx2.example.net.
+_sip._tcp   SRV   10 5 5060 sip.example.ch.
+@           TXT   "v=spf1 include:_spf.example.net" " -all"
+@           CAA   0 issue "letsencrypt.org"
+sub         NS    ns.sub.example.ch.
+`
+
+func TestParseBIND(t *testing.T) {
+	t.Parallel()
+
+	records, err := ParseBIND(strings.NewReader(sampleZone), "example.ch")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []infomaniak.RecordInput{
+		{Source: ".", Type: "A", TTL: 300, Target: "192.0.2.1"},
+		{Source: "www", Type: "CNAME", TTL: 3600, Target: "example.ch"},
+		{Source: "www", Type: "AAAA", TTL: 3600, Target: "2001:db8::1"},
+		{Source: ".", Type: "MX", TTL: 3600, Target: "mx1.example.ch", Priority: 10},
+		{Source: ".", Type: "MX", TTL: 3600, Target: "mx2.example.net", Priority: 20},
+		{Source: "_sip._tcp", Type: "SRV", TTL: 3600, Target: "sip.example.ch", Priority: 10, Weight: 5, Port: 5060},
+		{Source: ".", Type: "TXT", TTL: 3600, Target: "v=spf1 include:_spf.example.net -all"},
+		{Source: ".", Type: "CAA", TTL: 3600, Target: `0 issue "letsencrypt.org"`},
+		{Source: "sub", Type: "NS", TTL: 3600, Target: "ns.sub.example.ch"},
+	}
+
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d: %+v", len(records), len(want), records)
+	}
+	for i := range want {
+		if records[i] != want[i] {
+			t.Errorf("record %d = %+v, want %+v", i, records[i], want[i])
+		}
+	}
+}
+
+func TestParseBINDErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "unbalanced parenthesis", data: "@ IN SOA a. b. ( 1 2 3 4 5\n"},
+		{name: "unterminated string", data: "@ IN TXT \"oops\n"},
+		{name: "out of zone", data: "www.other.ch. IN A 192.0.2.1\n"},
+		{name: "include", data: "$INCLUDE other.db\n"},
+		{name: "bad mx", data: "@ IN MX mx.example.ch.\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := ParseBIND(strings.NewReader(tt.data), "example.ch"); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestWriteBINDRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	live := []infomaniak.Record{
+		{ID: 3, Source: ".", Type: "MX", TTL: 3600, Target: "mx.example.ch", Priority: 10},
+		{ID: 1, Source: "www", Type: "A", TTL: 300, Target: "192.0.2.1"},
+		{ID: 2, Source: ".", Type: "TXT", TTL: 3600, Target: `say "hi"`},
+		{ID: 4, Source: "_sip._tcp", Type: "SRV", TTL: 3600, Target: "sip.example.ch", Priority: 10, Weight: 5, Port: 5060},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteBIND(&buf, "example.ch", live); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"$ORIGIN example.ch.\n",
+		"$TTL 3600\n",
+		"@         3600 IN MX  10 mx.example.ch.\n",
+		`@         3600 IN TXT "say \"hi\""` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	parsed, err := ParseBIND(strings.NewReader(out), "example.ch")
+	if err != nil {
+		t.Fatalf("parse exported zone: %v", err)
+	}
+	if plan := Compute(live, parsed, true); !plan.Empty() {
+		var diff bytes.Buffer
+		plan.Render(&diff)
+		t.Errorf("round trip is not stable:\n%s", diff.String())
+	}
+}
+
+func TestMissing(t *testing.T) {
+	t.Parallel()
+
+	live := []infomaniak.Record{
+		{ID: 1, Source: "www", Type: "A", TTL: 3600, Target: "192.0.2.1"},
+	}
+	desired := []infomaniak.RecordInput{
+		{Source: "www", Type: "A", TTL: 300, Target: "192.0.2.1"},
+		{Source: "www", Type: "A", TTL: 300, Target: "192.0.2.2"},
+		{Source: "www", Type: "A", TTL: 300, Target: "192.0.2.2"},
+	}
+
+	plan := Missing(live, desired)
+	if got := plan.Count(ActionCreate); got != 1 || len(plan.Changes) != 1 {
+		t.Fatalf("changes = %+v, want a single create", plan.Changes)
+	}
+	if plan.Changes[0].Desired.Target != "192.0.2.2" {
+		t.Errorf("target = %q, want %q", plan.Changes[0].Desired.Target, "192.0.2.2")
+	}
+}
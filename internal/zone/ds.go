@@ -0,0 +1,85 @@
+package zone
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+// digestLengths maps DS digest types to their digest size in bytes
+// (RFC 4034, RFC 4509, RFC 6605).
+var digestLengths = map[int]int{
+	1: 20, // SHA-1
+	2: 32, // SHA-256
+	4: 48, // SHA-384
+}
+
+// ParseDS parses a DS record in presentation format. Both the bare rdata
+// ("12345 13 2 ABCD...") and a full resource record as printed by
+// dnssec-dsfromkey ("example.ch. 3600 IN DS 12345 13 2 ABCD...") are
+// accepted. Digests split over several fields are joined.
+func ParseDS(s string) (infomaniak.DSRecordInput, error) {
+	fields := strings.Fields(s)
+	for i, f := range fields {
+		if strings.EqualFold(f, "DS") {
+			fields = fields[i+1:]
+			break
+		}
+	}
+
+	if len(fields) < 4 {
+		return infomaniak.DSRecordInput{}, fmt.Errorf("ds record %q: want <key-tag> <algorithm> <digest-type> <digest>", s)
+	}
+
+	var nums [3]int
+	for i, name := range []string{"key tag", "algorithm", "digest type"} {
+		n, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return infomaniak.DSRecordInput{}, fmt.Errorf("ds record %q: invalid %s %q", s, name, fields[i])
+		}
+		nums[i] = n
+	}
+
+	input := infomaniak.DSRecordInput{
+		KeyTag:     nums[0],
+		Algorithm:  nums[1],
+		DigestType: nums[2],
+		Digest:     strings.Join(fields[3:], ""),
+	}
+
+	if err := ValidateDS(input); err != nil {
+		return infomaniak.DSRecordInput{}, err
+	}
+
+	input.Digest = strings.ToUpper(input.Digest)
+	return input, nil
+}
+
+// ValidateDS checks the ranges of the numeric fields and that the digest is
+// hex of the length its digest type requires.
+func ValidateDS(input infomaniak.DSRecordInput) error {
+	if input.KeyTag < 0 || input.KeyTag > 65535 {
+		return fmt.Errorf("key tag %d out of range 0-65535", input.KeyTag)
+	}
+	if input.Algorithm < 1 || input.Algorithm > 255 {
+		return fmt.Errorf("algorithm %d out of range 1-255", input.Algorithm)
+	}
+
+	size, ok := digestLengths[input.DigestType]
+	if !ok {
+		return fmt.Errorf("unsupported digest type %d", input.DigestType)
+	}
+
+	digest, err := hex.DecodeString(input.Digest)
+	if err != nil {
+		return fmt.Errorf("digest is not valid hex: %w", err)
+	}
+	if len(digest) != size {
+		return fmt.Errorf("digest type %d requires %d hex characters, got %d", input.DigestType, size*2, len(input.Digest))
+	}
+
+	return nil
+}
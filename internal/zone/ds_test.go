@@ -0,0 +1,63 @@
+package zone
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+func TestParseDS(t *testing.T) {
+	t.Parallel()
+
+	sha256 := strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name    string
+		in      string
+		want    infomaniak.DSRecordInput
+		wantErr bool
+	}{
+		{
+			name: "rdata",
+			in:   "12345 13 2 " + sha256,
+			want: infomaniak.DSRecordInput{KeyTag: 12345, Algorithm: 13, DigestType: 2, Digest: strings.ToUpper(sha256)},
+		},
+		{
+			name: "full record with split digest",
+			in:   "example.ch. 3600 IN DS 2371 8 2 " + sha256[:32] + " " + sha256[32:],
+			want: infomaniak.DSRecordInput{KeyTag: 2371, Algorithm: 8, DigestType: 2, Digest: strings.ToUpper(sha256)},
+		},
+		{
+			name: "sha1",
+			in:   "1 8 1 " + strings.Repeat("0", 40),
+			want: infomaniak.DSRecordInput{KeyTag: 1, Algorithm: 8, DigestType: 1, Digest: strings.Repeat("0", 40)},
+		},
+		{name: "too few fields", in: "12345 13 2", wantErr: true},
+		{name: "bad key tag", in: "x 13 2 " + sha256, wantErr: true},
+		{name: "key tag out of range", in: "70000 13 2 " + sha256, wantErr: true},
+		{name: "unknown digest type", in: "1 13 3 " + sha256, wantErr: true},
+		{name: "wrong digest length", in: "1 13 2 " + sha256[:40], wantErr: true},
+		{name: "non-hex digest", in: "1 13 2 " + strings.Repeat("zz", 32), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseDS(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDS() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
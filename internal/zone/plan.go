@@ -0,0 +1,267 @@
+package zone
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+// Action describes what a Change does to a record.
+type Action string
+
+// Actions a plan can contain.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is a single step of a Plan. Current is nil for creates and Desired
+// is nil for deletes.
+type Change struct {
+	Action  Action                  `json:"action"`
+	Current *infomaniak.Record      `json:"current,omitempty"`
+	Desired *infomaniak.RecordInput `json:"desired,omitempty"`
+}
+
+// Plan is the ordered list of changes that reconcile a zone.
+type Plan struct {
+	Changes []Change `json:"changes"`
+}
+
+// Empty reports whether the plan contains no changes.
+func (p Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Count returns the number of changes with the given action.
+func (p Plan) Count(a Action) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == a {
+			n++
+		}
+	}
+	return n
+}
+
+type recordKey struct {
+	source string
+	typ    string
+}
+
+// Compute diffs the live records against the desired ones. Records are
+// grouped by source and type; within a group, records with identical targets
+// are paired first, and any remaining records are paired in order and become
+// updates. Live records left over are only deleted when prune is set, so
+// partial zone files never remove records they do not mention.
+func Compute(live []infomaniak.Record, desired []infomaniak.RecordInput, prune bool) Plan {
+	liveByKey := make(map[recordKey][]infomaniak.Record)
+	for _, r := range live {
+		k := recordKey{normalizeSource(r.Source), strings.ToUpper(r.Type)}
+		liveByKey[k] = append(liveByKey[k], r)
+	}
+
+	wantByKey := make(map[recordKey][]infomaniak.RecordInput)
+	var keys []recordKey
+	for _, d := range desired {
+		d = normalize(d)
+		k := recordKey{d.Source, d.Type}
+		if _, ok := wantByKey[k]; !ok {
+			keys = append(keys, k)
+		}
+		wantByKey[k] = append(wantByKey[k], d)
+	}
+	for k := range liveByKey {
+		if _, ok := wantByKey[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].source != keys[j].source {
+			return keys[i].source < keys[j].source
+		}
+		return keys[i].typ < keys[j].typ
+	})
+
+	var plan Plan
+	for _, k := range keys {
+		plan.Changes = append(plan.Changes, diffGroup(liveByKey[k], wantByKey[k], prune)...)
+	}
+
+	return plan
+}
+
+// Missing returns a plan that only creates the desired records without an
+// exact counterpart (same source, type and target) among the live ones.
+func Missing(live []infomaniak.Record, desired []infomaniak.RecordInput) Plan {
+	type exactKey struct {
+		recordKey
+		target string
+	}
+
+	have := make(map[exactKey]bool, len(live))
+	for _, r := range live {
+		have[exactKey{recordKey{normalizeSource(r.Source), strings.ToUpper(r.Type)}, targetKey(r.Type, r.Target)}] = true
+	}
+
+	var plan Plan
+	for _, d := range desired {
+		d = normalize(d)
+		k := exactKey{recordKey{d.Source, d.Type}, targetKey(d.Type, d.Target)}
+		if have[k] {
+			continue
+		}
+		have[k] = true
+		plan.Changes = append(plan.Changes, Change{Action: ActionCreate, Desired: &d})
+	}
+
+	return plan
+}
+
+func diffGroup(live []infomaniak.Record, want []infomaniak.RecordInput, prune bool) []Change {
+	var changes []Change
+
+	// Pair records that already point at the desired target.
+	used := make([]bool, len(live))
+	var unmatched []infomaniak.RecordInput
+	for _, w := range want {
+		idx := -1
+		for i, r := range live {
+			if !used[i] && sameTarget(r.Type, r.Target, w.Target) {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			unmatched = append(unmatched, w)
+			continue
+		}
+		used[idx] = true
+		if !sameRecord(live[idx], w) {
+			changes = append(changes, updateChange(live[idx], w))
+		}
+	}
+
+	var spare []infomaniak.Record
+	for i, r := range live {
+		if !used[i] {
+			spare = append(spare, r)
+		}
+	}
+
+	// Reuse spare live records for the remaining desired ones.
+	for _, w := range unmatched {
+		if len(spare) > 0 {
+			changes = append(changes, updateChange(spare[0], w))
+			spare = spare[1:]
+			continue
+		}
+		changes = append(changes, Change{Action: ActionCreate, Desired: &w})
+	}
+
+	if prune {
+		for _, r := range spare {
+			if providerManaged(r) {
+				continue
+			}
+			changes = append(changes, Change{Action: ActionDelete, Current: &r})
+		}
+	}
+
+	return changes
+}
+
+// providerManaged reports whether the provider maintains r itself: the SOA
+// and the apex NS records. Zone files leave them out, as BIND imports do, so
+// pruning must not delete them.
+func providerManaged(r infomaniak.Record) bool {
+	typ := strings.ToUpper(r.Type)
+	return typ == "SOA" || (typ == "NS" && normalizeSource(r.Source) == ".")
+}
+
+func updateChange(current infomaniak.Record, desired infomaniak.RecordInput) Change {
+	return Change{Action: ActionUpdate, Current: &current, Desired: &desired}
+}
+
+func sameRecord(r infomaniak.Record, in infomaniak.RecordInput) bool {
+	return sameTarget(r.Type, r.Target, in.Target) &&
+		r.TTL == in.TTL &&
+		r.Priority == in.Priority &&
+		r.Weight == in.Weight &&
+		r.Port == in.Port
+}
+
+// nameTypes are the record types whose target is a domain name.
+var nameTypes = map[string]bool{
+	"CNAME": true,
+	"MX":    true,
+	"NS":    true,
+	"SRV":   true,
+	"PTR":   true,
+}
+
+// targetKey returns the form of target that is compared. Domain names are
+// compared without regard to case or a trailing dot, so "Mail.example.ch."
+// matches "mail.example.ch".
+func targetKey(typ, target string) string {
+	target = strings.TrimSpace(target)
+	if nameTypes[strings.ToUpper(typ)] {
+		return strings.TrimSuffix(strings.ToLower(target), ".")
+	}
+	return target
+}
+
+func sameTarget(typ, a, b string) bool {
+	return targetKey(typ, a) == targetKey(typ, b)
+}
+
+// Render writes a human-readable diff of the plan to w, in the spirit of
+// `terraform plan`.
+func (p Plan) Render(w io.Writer) {
+	for _, c := range p.Changes {
+		switch c.Action {
+		case ActionCreate:
+			fmt.Fprintf(w, "  + %s\n", formatInput(*c.Desired))
+		case ActionDelete:
+			fmt.Fprintf(w, "  - %s\n", formatRecord(*c.Current))
+		case ActionUpdate:
+			fmt.Fprintf(w, "  ~ %s %s (id %d)\n", c.Current.Source, c.Current.Type, c.Current.ID)
+			renderField(w, "target", c.Current.Target, c.Desired.Target)
+			renderField(w, "ttl", c.Current.TTL, c.Desired.TTL)
+			renderField(w, "priority", c.Current.Priority, c.Desired.Priority)
+			renderField(w, "weight", c.Current.Weight, c.Desired.Weight)
+			renderField(w, "port", c.Current.Port, c.Desired.Port)
+		}
+	}
+
+	fmt.Fprintf(w, "\nPlan: %d to add, %d to change, %d to destroy.\n",
+		p.Count(ActionCreate), p.Count(ActionUpdate), p.Count(ActionDelete))
+}
+
+func renderField[T comparable](w io.Writer, name string, from, to T) {
+	if from != to {
+		fmt.Fprintf(w, "      %s: %v -> %v\n", name, from, to)
+	}
+}
+
+func formatRecord(r infomaniak.Record) string {
+	return formatInput(infomaniak.RecordInput{
+		Source: r.Source, Type: r.Type, TTL: r.TTL, Target: r.Target,
+		Priority: r.Priority, Weight: r.Weight, Port: r.Port,
+	})
+}
+
+func formatInput(in infomaniak.RecordInput) string {
+	switch strings.ToUpper(in.Type) {
+	case "MX":
+		return fmt.Sprintf("%s %d %s %d %s", in.Source, in.TTL, in.Type, in.Priority, in.Target)
+	case "SRV":
+		return fmt.Sprintf("%s %d %s %d %d %d %s", in.Source, in.TTL, in.Type, in.Priority, in.Weight, in.Port, in.Target)
+	default:
+		return fmt.Sprintf("%s %d %s %s", in.Source, in.TTL, in.Type, in.Target)
+	}
+}
@@ -0,0 +1,186 @@
+package zone
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+)
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+
+	live := []infomaniak.Record{
+		{ID: 1, Source: ".", Type: "A", TTL: 3600, Target: "192.0.2.1"},
+		{ID: 2, Source: "www", Type: "CNAME", TTL: 3600, Target: "example.ch"},
+		{ID: 3, Source: ".", Type: "MX", TTL: 3600, Target: "mx1.example.ch", Priority: 10},
+		{ID: 4, Source: "old", Type: "A", TTL: 3600, Target: "192.0.2.9"},
+	}
+
+	tests := []struct {
+		name        string
+		desired     []infomaniak.RecordInput
+		prune       bool
+		wantCreate  int
+		wantUpdate  int
+		wantDelete  int
+		wantUpdated []int
+	}{
+		{
+			name: "no changes",
+			desired: []infomaniak.RecordInput{
+				{Source: "@", Type: "a", TTL: 3600, Target: "192.0.2.1"},
+				{Source: "www", Type: "CNAME", TTL: 3600, Target: "example.ch"},
+				{Source: "", Type: "MX", TTL: 3600, Target: "mx1.example.ch", Priority: 10},
+				{Source: "old", Type: "A", TTL: 3600, Target: "192.0.2.9"},
+			},
+		},
+		{
+			name: "partial file without prune keeps unmentioned records",
+			desired: []infomaniak.RecordInput{
+				{Source: ".", Type: "A", TTL: 300, Target: "192.0.2.1"},
+			},
+			wantUpdate:  1,
+			wantUpdated: []int{1},
+		},
+		{
+			name: "prune deletes unmentioned records",
+			desired: []infomaniak.RecordInput{
+				{Source: ".", Type: "A", TTL: 3600, Target: "192.0.2.1"},
+			},
+			prune:      true,
+			wantDelete: 3,
+		},
+		{
+			name: "changed target reuses existing record",
+			desired: []infomaniak.RecordInput{
+				{Source: "www", Type: "CNAME", TTL: 3600, Target: "other.example.ch"},
+			},
+			wantUpdate:  1,
+			wantUpdated: []int{2},
+		},
+		{
+			name: "additional record in existing set is created",
+			desired: []infomaniak.RecordInput{
+				{Source: ".", Type: "MX", TTL: 3600, Target: "mx1.example.ch", Priority: 10},
+				{Source: ".", Type: "MX", TTL: 3600, Target: "mx2.example.ch", Priority: 20},
+				{Source: "api", Type: "AAAA", TTL: 3600, Target: "2001:db8::1"},
+			},
+			wantCreate: 2,
+		},
+		{
+			name: "name targets ignore case and trailing dot",
+			desired: []infomaniak.RecordInput{
+				{Source: "www", Type: "CNAME", TTL: 3600, Target: "Example.CH."},
+				{Source: ".", Type: "MX", TTL: 3600, Target: "mx1.example.ch.", Priority: 10},
+			},
+		},
+		{
+			name: "other targets are compared exactly",
+			desired: []infomaniak.RecordInput{
+				{Source: "old", Type: "A", TTL: 3600, Target: "192.0.2.9."},
+			},
+			wantUpdate:  1,
+			wantUpdated: []int{4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan := Compute(live, tt.desired, tt.prune)
+
+			if got := plan.Count(ActionCreate); got != tt.wantCreate {
+				t.Errorf("creates = %d, want %d", got, tt.wantCreate)
+			}
+			if got := plan.Count(ActionUpdate); got != tt.wantUpdate {
+				t.Errorf("updates = %d, want %d", got, tt.wantUpdate)
+			}
+			if got := plan.Count(ActionDelete); got != tt.wantDelete {
+				t.Errorf("deletes = %d, want %d", got, tt.wantDelete)
+			}
+
+			var updated []int
+			for _, c := range plan.Changes {
+				if c.Action == ActionUpdate {
+					updated = append(updated, c.Current.ID)
+				}
+			}
+			if len(tt.wantUpdated) > 0 && !equalInts(updated, tt.wantUpdated) {
+				t.Errorf("updated ids = %v, want %v", updated, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestComputePruneKeepsProviderRecords(t *testing.T) {
+	t.Parallel()
+
+	live := []infomaniak.Record{
+		{ID: 1, Source: ".", Type: "SOA", TTL: 3600, Target: "ns11.infomaniak.ch. hostmaster.infomaniak.ch. 1 10800 3600 605800 3600"},
+		{ID: 2, Source: ".", Type: "NS", TTL: 3600, Target: "ns11.infomaniak.ch"},
+		{ID: 3, Source: ".", Type: "NS", TTL: 3600, Target: "ns12.infomaniak.ch"},
+		{ID: 4, Source: "sub", Type: "NS", TTL: 3600, Target: "ns1.other.net"},
+		{ID: 5, Source: "www", Type: "A", TTL: 3600, Target: "192.0.2.1"},
+	}
+	desired := []infomaniak.RecordInput{
+		{Source: "www", Type: "A", TTL: 3600, Target: "192.0.2.1"},
+	}
+
+	plan := Compute(live, desired, true)
+
+	var deleted []int
+	for _, c := range plan.Changes {
+		if c.Action != ActionDelete {
+			t.Errorf("unexpected %s of record %+v", c.Action, c.Current)
+			continue
+		}
+		deleted = append(deleted, c.Current.ID)
+	}
+	if !equalInts(deleted, []int{4}) {
+		t.Errorf("deleted ids = %v, want only the delegation of sub [4]", deleted)
+	}
+}
+
+func TestPlanRender(t *testing.T) {
+	t.Parallel()
+
+	live := []infomaniak.Record{
+		{ID: 1, Source: ".", Type: "A", TTL: 3600, Target: "192.0.2.1"},
+		{ID: 2, Source: "old", Type: "TXT", TTL: 3600, Target: "bye"},
+	}
+	desired := []infomaniak.RecordInput{
+		{Source: ".", Type: "A", TTL: 300, Target: "192.0.2.1"},
+		{Source: "www", Type: "CNAME", TTL: 3600, Target: "example.ch"},
+	}
+
+	var buf bytes.Buffer
+	Compute(live, desired, true).Render(&buf)
+	out := buf.String()
+
+	for _, want := range []string{
+		"  ~ . A (id 1)",
+		"      ttl: 3600 -> 300",
+		"  + www 3600 CNAME example.ch",
+		"  - old 3600 TXT bye",
+		"Plan: 1 to add, 1 to change, 1 to destroy.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
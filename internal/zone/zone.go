@@ -0,0 +1,130 @@
+// Package zone models the desired state of a DNS zone and computes the
+// changes required to reconcile it with the records served by the API.
+package zone
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/yannick/infomaniak/pkg/infomaniak"
+	"go.yaml.in/yaml/v3"
+)
+
+// DefaultTTL is used for desired records that do not specify a TTL.
+const DefaultTTL = 3600
+
+// File is the on-disk desired-state description of a zone. JSON files are
+// accepted as well since JSON is a subset of YAML.
+type File struct {
+	Domain  string       `yaml:"domain" json:"domain"`
+	TTL     int          `yaml:"ttl,omitempty" json:"ttl,omitempty"`
+	Records []FileRecord `yaml:"records" json:"records"`
+}
+
+// FileRecord is a single record entry of a zone File.
+type FileRecord struct {
+	Source   string `yaml:"source" json:"source"`
+	Type     string `yaml:"type" json:"type"`
+	TTL      int    `yaml:"ttl,omitempty" json:"ttl,omitempty"`
+	Target   string `yaml:"target" json:"target"`
+	Priority int    `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Weight   int    `yaml:"weight,omitempty" json:"weight,omitempty"`
+	Port     int    `yaml:"port,omitempty" json:"port,omitempty"`
+}
+
+// Load reads and validates a zone file from path.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read zone file: %w", err)
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse zone file %s: %w", path, err)
+	}
+
+	return f, nil
+}
+
+// Parse decodes and validates a zone file from YAML or JSON data.
+func Parse(data []byte) (*File, error) {
+	var f File
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+
+	for i, r := range f.Records {
+		if r.Type == "" {
+			return nil, fmt.Errorf("record %d: type is required", i+1)
+		}
+		if r.Target == "" {
+			return nil, fmt.Errorf("record %d: target is required", i+1)
+		}
+	}
+
+	return &f, nil
+}
+
+// Desired returns the normalized record inputs described by the file,
+// applying the file-level TTL and DefaultTTL where records omit one.
+func (f *File) Desired() []infomaniak.RecordInput {
+	ttl := f.TTL
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+
+	out := make([]infomaniak.RecordInput, 0, len(f.Records))
+	for _, r := range f.Records {
+		in := infomaniak.RecordInput{
+			Source:   r.Source,
+			Type:     r.Type,
+			TTL:      r.TTL,
+			Target:   r.Target,
+			Priority: r.Priority,
+			Weight:   r.Weight,
+			Port:     r.Port,
+		}
+		if in.TTL == 0 {
+			in.TTL = ttl
+		}
+		out = append(out, normalize(in))
+	}
+
+	return out
+}
+
+// FromRecords builds a zone File describing the given live records.
+func FromRecords(domain string, records []infomaniak.Record) *File {
+	f := &File{Domain: domain, Records: make([]FileRecord, 0, len(records))}
+	for _, r := range records {
+		f.Records = append(f.Records, FileRecord{
+			Source:   r.Source,
+			Type:     r.Type,
+			TTL:      r.TTL,
+			Target:   r.Target,
+			Priority: r.Priority,
+			Weight:   r.Weight,
+			Port:     r.Port,
+		})
+	}
+	return f
+}
+
+// normalize canonicalizes the fields used to match records so that "@",
+// "" and "." all refer to the apex and type comparisons ignore case.
+func normalize(in infomaniak.RecordInput) infomaniak.RecordInput {
+	in.Type = strings.ToUpper(strings.TrimSpace(in.Type))
+	in.Source = normalizeSource(in.Source)
+	in.Target = strings.TrimSpace(in.Target)
+	return in
+}
+
+func normalizeSource(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == "@" {
+		return "."
+	}
+	return s
+}
@@ -0,0 +1,74 @@
+package zone
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		wantLen int
+		wantTTL int
+		wantErr bool
+	}{
+		{
+			name: "yaml with file-level ttl",
+			data: `
+domain: example.ch
+ttl: 600
+records:
+  - source: "@"
+    type: a
+    target: 192.0.2.1
+  - source: www
+    type: CNAME
+    ttl: 300
+    target: example.ch
+`,
+			wantLen: 2,
+			wantTTL: 600,
+		},
+		{
+			name:    "json",
+			data:    `{"domain": "example.ch", "records": [{"source": "www", "type": "A", "target": "192.0.2.1"}]}`,
+			wantLen: 1,
+			wantTTL: DefaultTTL,
+		},
+		{
+			name:    "missing target",
+			data:    "records:\n  - source: www\n    type: A\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := Parse([]byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			desired := f.Desired()
+			if len(desired) != tt.wantLen {
+				t.Fatalf("got %d records, want %d", len(desired), tt.wantLen)
+			}
+			if desired[0].TTL != tt.wantTTL {
+				t.Errorf("ttl = %d, want %d", desired[0].TTL, tt.wantTTL)
+			}
+			if desired[0].Type != "A" {
+				t.Errorf("type = %q, want normalized %q", desired[0].Type, "A")
+			}
+		})
+	}
+}
@@ -1,17 +1,90 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"sort"
 
 	"github.com/yannick/infomaniak/internal/cmd"
+	"github.com/yannick/infomaniak/pkg/infomaniak"
 )
 
 // version is set at build time via -ldflags.
 var version = "dev"
 
+// Exit codes let scripts tell API failures apart without parsing messages.
+// The typed codes stay clear of 2 and 3, which "domains expiring" uses for
+// the Nagios CRITICAL and UNKNOWN states; that command exits 3 on any error.
+const (
+	exitError        = 1
+	exitNotFound     = 4
+	exitValidation   = 5
+	exitRateLimited  = 6
+	exitUnauthorized = 7
+)
+
 func main() {
 	cmd.SetVersion(version)
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			if exitErr.Err != nil {
+				printError(os.Stderr, exitErr.Err)
+			}
+			os.Exit(exitErr.Code)
+		}
+		if errors.Is(err, infomaniak.ErrDryRun) {
+			fmt.Fprintln(os.Stderr, "Dry run: no changes were made.")
+			return
+		}
+		printError(os.Stderr, err)
+		os.Exit(exitCode(err))
+	}
+}
+
+func exitCode(err error) int {
+	switch {
+	case infomaniak.IsUnauthorized(err):
+		return exitUnauthorized
+	case infomaniak.IsNotFound(err):
+		return exitNotFound
+	case infomaniak.IsValidation(err):
+		return exitValidation
+	case infomaniak.IsRateLimited(err):
+		return exitRateLimited
+	default:
+		return exitError
+	}
+}
+
+// printError writes err and, for validation failures, one line per field.
+func printError(w io.Writer, err error) {
+	fmt.Fprintf(w, "Error: %v\n", err)
+
+	var apiErr *infomaniak.Error
+	if !errors.As(err, &apiErr) {
+		return
+	}
+
+	for _, d := range apiErr.Details {
+		field := d.Context["attribute"]
+		if field == "" {
+			field = d.Code
+		}
+		fmt.Fprintf(w, "  %s: %s", field, d.Description)
+
+		keys := make([]string, 0, len(d.Context))
+		for k := range d.Context {
+			if k != "attribute" {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(w, " (%s=%s)", k, d.Context[k])
+		}
+		fmt.Fprintln(w)
 	}
 }
@@ -0,0 +1,61 @@
+package infomaniak
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// AuditEntry describes a write request that was sent and its outcome.
+type AuditEntry struct {
+	Time   time.Time
+	Method string
+	Path   string
+	// Body is the request body with secret fields redacted, or nil.
+	Body json.RawMessage
+	// Status is the HTTP status of the response, or 0 when none was
+	// received.
+	Status int
+	// Err is the transport failure or the API error the response carried.
+	Err error
+}
+
+// audit reports a write request to the client's audit hook. For failed
+// responses the body is read to extract the API error and then restored so
+// that the caller can decode it as usual.
+func (c *Client) audit(start time.Time, method, path string, payload []byte, resp *http.Response, err error) {
+	if c.auditFn == nil || isRead(method) {
+		return
+	}
+
+	entry := AuditEntry{
+		Time:   start,
+		Method: method,
+		Path:   path,
+		Err:    err,
+	}
+	if len(payload) > 0 && json.Valid(payload) {
+		entry.Body = redactCompact(payload)
+	}
+
+	if resp != nil {
+		entry.Status = resp.StatusCode
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			data, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(data))
+
+			copied := *resp
+			copied.Body = io.NopCloser(bytes.NewReader(data))
+			if readErr != nil {
+				entry.Err = readErr
+			} else {
+				entry.Err = decodeEmptyResponse(&copied)
+			}
+		}
+	}
+
+	c.auditFn(entry)
+}
@@ -0,0 +1,101 @@
+package infomaniak
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAudit(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(Response[Domain]{Result: "success", Data: Domain{Name: "example.ch"}})
+		case strings.HasPrefix(r.URL.Path, "/2/domains/transfers"):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_ = json.NewEncoder(w).Encode(Response[any]{
+				Result: "error",
+				Error:  &ErrorBody{Code: "invalid_auth_code", Description: "the auth code is wrong"},
+			})
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	var (
+		mu      sync.Mutex
+		entries []AuditEntry
+	)
+	c := New("t", WithBaseURL(srv.URL), WithAudit(func(e AuditEntry) {
+		mu.Lock()
+		defer mu.Unlock()
+		entries = append(entries, e)
+	}))
+	ctx := context.Background()
+
+	if _, err := c.ShowDomain(ctx, "example.ch"); err != nil {
+		t.Fatalf("show domain: %v", err)
+	}
+	if err := c.UpdateNameservers(ctx, "example.ch", UpdateNameserversInput{Nameservers: []string{"ns1.example.net"}}); err != nil {
+		t.Fatalf("update nameservers: %v", err)
+	}
+	_, err := c.TransferIn(ctx, TransferInInput{Name: "example.com", AuthCode: "hunter2"})
+	if !IsValidation(err) {
+		t.Fatalf("transfer in: err = %v, want the validation error to still reach the caller", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("got %d audit entries, want 2 (reads are not audited): %+v", len(entries), entries)
+	}
+
+	ns := entries[0]
+	if ns.Method != http.MethodPut || ns.Path != "/2/domains/domains/example.ch/nameservers" || ns.Status != http.StatusNoContent || ns.Err != nil {
+		t.Errorf("nameserver entry = %+v", ns)
+	}
+	if want := `{"nameservers":["ns1.example.net"],"verify_ns_availability":false}`; string(ns.Body) != want {
+		t.Errorf("body = %s, want %s", ns.Body, want)
+	}
+	if ns.Time.IsZero() {
+		t.Error("entry has no time")
+	}
+
+	transfer := entries[1]
+	if transfer.Status != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want 422", transfer.Status)
+	}
+	var apiErr *Error
+	if !errors.As(transfer.Err, &apiErr) || apiErr.Code != "invalid_auth_code" {
+		t.Errorf("err = %v, want the API error", transfer.Err)
+	}
+	if strings.Contains(string(transfer.Body), "hunter2") || !strings.Contains(string(transfer.Body), `"auth_code":"<redacted>"`) {
+		t.Errorf("body not redacted: %s", transfer.Body)
+	}
+}
+
+func TestAuditSkipsDryRun(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	c := New("t",
+		WithBaseURL("http://127.0.0.1:0"),
+		WithDryRun(&strings.Builder{}),
+		WithAudit(func(AuditEntry) { called = true }),
+	)
+
+	err := c.UpdateNameservers(context.Background(), "example.ch", UpdateNameserversInput{Nameservers: []string{"ns1.example.net"}})
+	if !errors.Is(err, ErrDryRun) {
+		t.Fatalf("err = %v, want ErrDryRun", err)
+	}
+	if called {
+		t.Error("dry-run request was audited")
+	}
+}
@@ -0,0 +1,258 @@
+// Package infomaniak is a client for the Infomaniak domain and DNS API.
+//
+// A Client is created with New and configured with options:
+//
+//	c := infomaniak.New(token,
+//		infomaniak.WithUserAgent("my-controller/1.0"),
+//		infomaniak.WithTimeout(10*time.Second),
+//		infomaniak.WithMiddleware(metrics),
+//	)
+//	domains, err := c.ListDomains(ctx)
+//
+// Failed API calls return an *Error; IsNotFound, IsValidation and the other
+// helpers classify it.
+package infomaniak
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the Infomaniak API endpoint used unless WithBaseURL
+// overrides it.
+const DefaultBaseURL = "https://api.infomaniak.com"
+
+// DefaultTimeout bounds each HTTP request unless WithTimeout or
+// WithHTTPClient overrides it.
+const DefaultTimeout = 30 * time.Second
+
+// DefaultUserAgent is sent unless WithUserAgent overrides it.
+const DefaultUserAgent = "infomaniak-go"
+
+// Client communicates with the Infomaniak API. It is safe for concurrent
+// use.
+type Client struct {
+	baseURL    string
+	token      string
+	accountID  string
+	userAgent  string
+	httpClient *http.Client
+	retry      RetryPolicy
+	limiter    *limiter
+	dryRun     io.Writer
+	auditFn    func(AuditEntry)
+}
+
+// New creates a client that authenticates with the given API token.
+func New(token string, opts ...Option) *Client {
+	o := clientOptions{
+		baseURL:   DefaultBaseURL,
+		userAgent: DefaultUserAgent,
+		retry:     DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c := &Client{
+		baseURL:    o.baseURL,
+		token:      token,
+		accountID:  o.accountID,
+		userAgent:  o.userAgent,
+		httpClient: o.buildHTTPClient(),
+		retry:      o.retry,
+		dryRun:     o.dryRun,
+		auditFn:    o.audit,
+	}
+	if o.rateLimit > 0 {
+		c.limiter = newLimiter(o.rateLimit, o.rateBurst)
+	}
+	return c
+}
+
+// doRequest sends a request, retrying transient failures according to the
+// client's RetryPolicy. The body is buffered so it can be replayed.
+func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("read request body %s %s: %w", method, path, err)
+		}
+	}
+
+	if c.dryRun != nil && !isRead(method) {
+		if err := c.describeRequest(c.dryRun, method, path, payload); err != nil {
+			return nil, fmt.Errorf("describe request %s %s: %w", method, path, err)
+		}
+		return nil, ErrDryRun
+	}
+
+	attempts := max(c.retry.MaxAttempts, 1)
+	if !c.retry.allowsMethod(method) {
+		attempts = 1
+	}
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, path, payload)
+
+		retry := attempt < attempts
+		if err != nil {
+			retry = retry && retryableError(err)
+		} else {
+			retry = retry && retryableStatus(resp.StatusCode)
+		}
+		if !retry {
+			c.audit(start, method, path, payload, resp, err)
+			return resp, err
+		}
+
+		delay := c.retry.backoff(attempt, resp, time.Now())
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			// Waiting would use up the caller's deadline and replace the
+			// API's answer with a context error.
+			c.audit(start, method, path, payload, resp, err)
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			err = fmt.Errorf("execute request %s %s: %w", method, path, err)
+			c.audit(start, method, path, payload, nil, err)
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, path string, payload []byte) (*http.Response, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("wait for rate limit %s %s: %w", method, path, err)
+		}
+	}
+
+	url := c.baseURL + path
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("create request %s %s: %w", method, path, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute request %s %s: %w", method, path, err)
+	}
+
+	return resp, nil
+}
+
+// maxSnippet is how much of an unexpected response body is quoted in errors.
+const maxSnippet = 200
+
+// decodeResponse reads an API response envelope and turns API failures into
+// *Error. An empty body is an error since callers expect data.
+func decodeResponse[T any](resp *http.Response) (*Response[T], error) {
+	return readResponse[T](resp, false)
+}
+
+// decodeEmptyResponse checks the response of a write endpoint whose data is
+// not needed. A 204 or an otherwise empty 2xx response counts as success.
+func decodeEmptyResponse(resp *http.Response) error {
+	_, err := readResponse[any](resp, true)
+	return err
+}
+
+func readResponse[T any](resp *http.Response, allowEmpty bool) (*Response[T], error) {
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	ok := resp.StatusCode >= 200 && resp.StatusCode < 300
+	trimmed := bytes.TrimSpace(data)
+
+	if len(trimmed) == 0 {
+		switch {
+		case !ok:
+			return nil, &Error{StatusCode: resp.StatusCode, Description: http.StatusText(resp.StatusCode)}
+		case allowEmpty:
+			return &Response[T]{Result: "success"}, nil
+		default:
+			return nil, fmt.Errorf("empty response body (status %d)", resp.StatusCode)
+		}
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if !isJSON(contentType, trimmed) {
+		return nil, unexpectedBody(resp.StatusCode, contentType, trimmed)
+	}
+
+	var result Response[T]
+	if err := json.Unmarshal(trimmed, &result); err != nil {
+		if !ok {
+			return nil, unexpectedBody(resp.StatusCode, contentType, trimmed)
+		}
+		return nil, fmt.Errorf("decode response (status %d): %w", resp.StatusCode, err)
+	}
+
+	if result.Result == "error" {
+		return nil, newError(resp.StatusCode, result.Error)
+	}
+
+	if !ok {
+		apiErr := newError(resp.StatusCode, result.Error)
+		apiErr.Description = http.StatusText(resp.StatusCode)
+		return nil, apiErr
+	}
+
+	return &result, nil
+}
+
+// isJSON reports whether a body should be decoded as JSON. Servers do not
+// always label JSON correctly, so an object-shaped body is accepted too.
+func isJSON(contentType string, body []byte) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		return true
+	}
+	return body[0] == '{'
+}
+
+// unexpectedBody describes a non-JSON response, such as an HTML error page
+// from a proxy, quoting the start of the body.
+func unexpectedBody(status int, contentType string, body []byte) error {
+	snippet := strings.Join(strings.Fields(string(body)), " ")
+	if len(snippet) > maxSnippet {
+		snippet = strings.ToValidUTF8(snippet[:maxSnippet], "") + "..."
+	}
+	if contentType == "" {
+		contentType = "unknown content type"
+	}
+
+	desc := fmt.Sprintf("unexpected response (%s): %s", contentType, snippet)
+	if status < 200 || status >= 300 {
+		return &Error{StatusCode: status, Description: desc}
+	}
+	return fmt.Errorf("%s (status %d)", desc, status)
+}
@@ -0,0 +1,247 @@
+package infomaniak
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        []Option
+		wantBaseURL string
+	}{
+		{
+			name:        "default base URL",
+			wantBaseURL: DefaultBaseURL,
+		},
+		{
+			name:        "custom base URL",
+			opts:        []Option{WithBaseURL("https://custom.api")},
+			wantBaseURL: "https://custom.api",
+		},
+		{
+			name:        "empty base URL keeps the default",
+			opts:        []Option{WithBaseURL("")},
+			wantBaseURL: DefaultBaseURL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := New("tok", tt.opts...)
+			if c.baseURL != tt.wantBaseURL {
+				t.Errorf("baseURL = %q, want %q", c.baseURL, tt.wantBaseURL)
+			}
+			if c.token != "tok" {
+				t.Errorf("token = %q, want %q", c.token, "tok")
+			}
+		})
+	}
+}
+
+func TestDecodeResponse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   int
+		body     Response[string]
+		wantData string
+		wantErr  bool
+	}{
+		{
+			name:     "success",
+			status:   http.StatusOK,
+			body:     Response[string]{Result: "success", Data: "hello"},
+			wantData: "hello",
+		},
+		{
+			name:   "api error",
+			status: http.StatusUnprocessableEntity,
+			body: Response[string]{
+				Result: "error",
+				Error:  &ErrorBody{Code: "validation_failed", Description: "bad input"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "error without body",
+			status:  http.StatusInternalServerError,
+			body:    Response[string]{Result: "error"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			w.WriteHeader(tt.status)
+			if err := json.NewEncoder(w).Encode(tt.body); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := decodeResponse[string](w.Result())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Data != tt.wantData {
+				t.Errorf("data = %q, want %q", result.Data, tt.wantData)
+			}
+		})
+	}
+}
+
+func TestDecodeResponseRawBodies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		allowEmpty  bool
+		wantErr     bool
+		wantStatus  int
+		wantInError string
+	}{
+		{
+			name:        "html error page from proxy",
+			status:      http.StatusBadGateway,
+			contentType: "text/html",
+			body:        "<html>\n  <body><h1>502 Bad Gateway</h1></body>\n</html>",
+			wantErr:     true,
+			wantStatus:  http.StatusBadGateway,
+			wantInError: "<html> <body><h1>502 Bad Gateway</h1></body> </html>",
+		},
+		{
+			name:        "html with success status",
+			status:      http.StatusOK,
+			contentType: "text/html; charset=utf-8",
+			body:        "<html>maintenance</html>",
+			wantErr:     true,
+			wantInError: "unexpected response (text/html; charset=utf-8)",
+		},
+		{
+			name:       "no content on write endpoint",
+			status:     http.StatusNoContent,
+			allowEmpty: true,
+		},
+		{
+			name:        "empty body on read endpoint",
+			status:      http.StatusOK,
+			wantErr:     true,
+			wantInError: "empty response body",
+		},
+		{
+			name:       "empty body with error status",
+			status:     http.StatusServiceUnavailable,
+			allowEmpty: true,
+			wantErr:    true,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:        "client error claiming success",
+			status:      http.StatusBadRequest,
+			contentType: "application/json",
+			body:        `{"result":"success","data":"hello"}`,
+			wantErr:     true,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "truncated json",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `{"result":"succ`,
+			wantErr:     true,
+			wantInError: "decode response (status 200)",
+		},
+		{
+			name:   "unlabelled json",
+			status: http.StatusOK,
+			body:   `{"result":"success","data":"hello"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &http.Response{
+				StatusCode: tt.status,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+			if tt.contentType != "" {
+				resp.Header.Set("Content-Type", tt.contentType)
+			}
+
+			_, err := readResponse[string](resp, tt.allowEmpty)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if tt.wantInError != "" && !strings.Contains(err.Error(), tt.wantInError) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.wantInError)
+			}
+
+			var apiErr *Error
+			isAPIErr := errors.As(err, &apiErr)
+			if tt.wantStatus != 0 && (!isAPIErr || apiErr.StatusCode != tt.wantStatus) {
+				t.Errorf("error = %#v, want *Error with status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestDoRequestSetsHeaders(t *testing.T) {
+	t.Parallel()
+
+	var gotAuth, gotContentType, gotUserAgent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotContentType = r.Header.Get("Content-Type")
+		gotUserAgent = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("test-token", WithBaseURL(srv.URL))
+	resp, err := c.doRequest(context.Background(), "GET", "/test", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if gotAuth != "Bearer test-token" {
+		t.Errorf("Authorization = %q, want %q", gotAuth, "Bearer test-token")
+	}
+	if gotContentType != "application/json" {
+		t.Errorf("Content-Type = %q, want %q", gotContentType, "application/json")
+	}
+	if gotUserAgent != DefaultUserAgent {
+		t.Errorf("User-Agent = %q, want %q", gotUserAgent, DefaultUserAgent)
+	}
+}
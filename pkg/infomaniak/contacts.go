@@ -0,0 +1,115 @@
+package infomaniak
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+)
+
+// ListContacts returns all domain contacts of the account.
+func (c *Client) ListContacts(ctx context.Context) ([]Contact, error) {
+	contacts, err := collect(c.IterContacts(ctx, ListOptions{}))
+	if err != nil {
+		return nil, fmt.Errorf("list contacts: %w", err)
+	}
+
+	return contacts, nil
+}
+
+// IterContacts iterates over the domain contacts of the account, fetching
+// pages on demand.
+func (c *Client) IterContacts(ctx context.Context, opts ListOptions) iter.Seq2[Contact, error] {
+	path := "/2/domains/contacts"
+	if c.accountID != "" {
+		path += "?account_id=" + url.QueryEscape(c.accountID)
+	}
+	return paginate[Contact](ctx, c, path, opts)
+}
+
+// ShowContact returns a single domain contact.
+func (c *Client) ShowContact(ctx context.Context, id int) (*Contact, error) {
+	path := fmt.Sprintf("/2/domains/contacts/%d", id)
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("show contact %d: %w", id, err)
+	}
+
+	result, err := decodeResponse[Contact](resp)
+	if err != nil {
+		return nil, fmt.Errorf("show contact %d: %w", id, err)
+	}
+
+	return &result.Data, nil
+}
+
+// CreateContact creates a new domain contact.
+func (c *Client) CreateContact(ctx context.Context, input ContactInput) (*Contact, error) {
+	path := "/2/domains/contacts"
+	if c.accountID != "" {
+		path += "?account_id=" + url.QueryEscape(c.accountID)
+	}
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("marshal contact: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create contact: %w", err)
+	}
+
+	result, err := decodeResponse[Contact](resp)
+	if err != nil {
+		return nil, fmt.Errorf("create contact: %w", err)
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateContact replaces the details of an existing domain contact.
+func (c *Client) UpdateContact(ctx context.Context, id int, input ContactInput) (*Contact, error) {
+	path := fmt.Sprintf("/2/domains/contacts/%d", id)
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("marshal contact %d: %w", id, err)
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("update contact %d: %w", id, err)
+	}
+
+	result, err := decodeResponse[Contact](resp)
+	if err != nil {
+		return nil, fmt.Errorf("update contact %d: %w", id, err)
+	}
+
+	return &result.Data, nil
+}
+
+// SetDomainContacts assigns existing contacts to the roles of a domain.
+func (c *Client) SetDomainContacts(ctx context.Context, domain string, input SetContactsInput) error {
+	path := fmt.Sprintf("/2/domains/domains/%s/contacts", domain)
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("marshal contacts for %s: %w", domain, err)
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("set contacts for %s: %w", domain, err)
+	}
+
+	if err := decodeEmptyResponse(resp); err != nil {
+		return fmt.Errorf("set contacts for %s: %w", domain, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package infomaniak
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListContacts(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2/domains/contacts" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/2/domains/contacts")
+		}
+		if got := r.URL.Query().Get("account_id"); got != "42" {
+			t.Errorf("account_id = %q, want %q", got, "42")
+		}
+		_ = json.NewEncoder(w).Encode(Response[[]Contact]{
+			Result: "success",
+			Data: []Contact{
+				{ID: 1, Email: "owner@example.ch", IsValidated: true},
+				{ID: 2, Email: "tech@example.ch"},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("tok", WithBaseURL(srv.URL), WithAccountID("42"))
+	contacts, err := c.ListContacts(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(contacts) != 2 || contacts[1].Email != "tech@example.ch" {
+		t.Errorf("contacts = %+v", contacts)
+	}
+}
+
+func TestContactWrites(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		wantMethod string
+		wantPath   string
+		wantBody   string
+		call       func(*Client) error
+	}{
+		{
+			name:       "create",
+			wantMethod: http.MethodPost,
+			wantPath:   "/2/domains/contacts",
+			wantBody:   `{"type":"individual","first_name":"Ada","email":"ada@example.ch"}`,
+			call: func(c *Client) error {
+				_, err := c.CreateContact(context.Background(), ContactInput{Type: ptr("individual"), FirstName: ptr("Ada"), Email: ptr("ada@example.ch")})
+				return err
+			},
+		},
+		{
+			name:       "update",
+			wantMethod: http.MethodPut,
+			wantPath:   "/2/domains/contacts/7",
+			wantBody:   `{"phone":"+41.221234567","fax":""}`,
+			call: func(c *Client) error {
+				_, err := c.UpdateContact(context.Background(), 7, ContactInput{Phone: ptr("+41.221234567"), Fax: ptr("")})
+				return err
+			},
+		},
+		{
+			name:       "set domain contacts",
+			wantMethod: http.MethodPut,
+			wantPath:   "/2/domains/domains/example.ch/contacts",
+			wantBody:   `{"owner":7,"tech":8}`,
+			call: func(c *Client) error {
+				return c.SetDomainContacts(context.Background(), "example.ch", SetContactsInput{Owner: 7, Tech: 8})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("path = %q, want %q", r.URL.Path, tt.wantPath)
+				}
+				if r.Method != tt.wantMethod {
+					t.Errorf("method = %q, want %q", r.Method, tt.wantMethod)
+				}
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != tt.wantBody {
+					t.Errorf("body = %s, want %s", body, tt.wantBody)
+				}
+				_ = json.NewEncoder(w).Encode(Response[Contact]{Result: "success", Data: Contact{ID: 7}})
+			}))
+			t.Cleanup(srv.Close)
+
+			c := New("tok", WithBaseURL(srv.URL))
+			if err := tt.call(c); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string { return &s }
@@ -0,0 +1,115 @@
+package infomaniak
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+)
+
+// ShowDNSSEC returns the DNSSEC state of a domain.
+func (c *Client) ShowDNSSEC(ctx context.Context, domain string) (*DNSSEC, error) {
+	path := fmt.Sprintf("/2/domains/domains/%s/dnssec", domain)
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("show dnssec for %s: %w", domain, err)
+	}
+
+	result, err := decodeResponse[DNSSEC](resp)
+	if err != nil {
+		return nil, fmt.Errorf("show dnssec for %s: %w", domain, err)
+	}
+
+	return &result.Data, nil
+}
+
+// EnableDNSSEC turns on DNSSEC for a domain using Infomaniak nameservers.
+func (c *Client) EnableDNSSEC(ctx context.Context, domain string) error {
+	path := fmt.Sprintf("/2/domains/domains/%s/dnssec", domain)
+
+	resp, err := c.doRequest(ctx, "POST", path, nil)
+	if err != nil {
+		return fmt.Errorf("enable dnssec for %s: %w", domain, err)
+	}
+
+	if err := decodeEmptyResponse(resp); err != nil {
+		return fmt.Errorf("enable dnssec for %s: %w", domain, err)
+	}
+
+	return nil
+}
+
+// DisableDNSSEC turns off DNSSEC for a domain.
+func (c *Client) DisableDNSSEC(ctx context.Context, domain string) error {
+	path := fmt.Sprintf("/2/domains/domains/%s/dnssec", domain)
+
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return fmt.Errorf("disable dnssec for %s: %w", domain, err)
+	}
+
+	if err := decodeEmptyResponse(resp); err != nil {
+		return fmt.Errorf("disable dnssec for %s: %w", domain, err)
+	}
+
+	return nil
+}
+
+// ListDSRecords returns the DS records published at the registry for a
+// domain.
+func (c *Client) ListDSRecords(ctx context.Context, domain string) ([]DSRecord, error) {
+	records, err := collect(c.IterDSRecords(ctx, domain, ListOptions{}))
+	if err != nil {
+		return nil, fmt.Errorf("list ds records for %s: %w", domain, err)
+	}
+
+	return records, nil
+}
+
+// IterDSRecords iterates over the DS records of a domain, fetching pages on
+// demand.
+func (c *Client) IterDSRecords(ctx context.Context, domain string, opts ListOptions) iter.Seq2[DSRecord, error] {
+	path := fmt.Sprintf("/2/domains/domains/%s/dnssec/ds", domain)
+	return paginate[DSRecord](ctx, c, path, opts)
+}
+
+// AddDSRecord publishes a DS record for a domain that uses external
+// nameservers.
+func (c *Client) AddDSRecord(ctx context.Context, domain string, input DSRecordInput) (*DSRecord, error) {
+	path := fmt.Sprintf("/2/domains/domains/%s/dnssec/ds", domain)
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("marshal ds record for %s: %w", domain, err)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("add ds record for %s: %w", domain, err)
+	}
+
+	result, err := decodeResponse[DSRecord](resp)
+	if err != nil {
+		return nil, fmt.Errorf("add ds record for %s: %w", domain, err)
+	}
+
+	return &result.Data, nil
+}
+
+// RemoveDSRecord withdraws a DS record from the registry.
+func (c *Client) RemoveDSRecord(ctx context.Context, domain string, id int) error {
+	path := fmt.Sprintf("/2/domains/domains/%s/dnssec/ds/%d", domain, id)
+
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return fmt.Errorf("remove ds record %d for %s: %w", id, domain, err)
+	}
+
+	if err := decodeEmptyResponse(resp); err != nil {
+		return fmt.Errorf("remove ds record %d for %s: %w", id, domain, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package infomaniak
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestShowDNSSEC(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2/domains/domains/example.ch/dnssec" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/2/domains/domains/example.ch/dnssec")
+		}
+		_ = json.NewEncoder(w).Encode(Response[DNSSEC]{
+			Result: "success",
+			Data: DNSSEC{
+				Enabled:   true,
+				DSRecords: []DSRecord{{ID: 1, KeyTag: 12345, Algorithm: 13, DigestType: 2, Digest: "abcd"}},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("tok", WithBaseURL(srv.URL))
+	state, err := c.ShowDNSSEC(context.Background(), "example.ch")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !state.Enabled || len(state.DSRecords) != 1 || state.DSRecords[0].KeyTag != 12345 {
+		t.Errorf("state = %+v", state)
+	}
+}
+
+func TestDNSSECWrites(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		wantMethod string
+		wantPath   string
+		status     int
+		call       func(*Client) error
+	}{
+		{
+			name:       "enable",
+			wantMethod: http.MethodPost,
+			wantPath:   "/2/domains/domains/example.ch/dnssec",
+			status:     http.StatusNoContent,
+			call: func(c *Client) error {
+				return c.EnableDNSSEC(context.Background(), "example.ch")
+			},
+		},
+		{
+			name:       "disable",
+			wantMethod: http.MethodDelete,
+			wantPath:   "/2/domains/domains/example.ch/dnssec",
+			status:     http.StatusNoContent,
+			call: func(c *Client) error {
+				return c.DisableDNSSEC(context.Background(), "example.ch")
+			},
+		},
+		{
+			name:       "add ds",
+			wantMethod: http.MethodPost,
+			wantPath:   "/2/domains/domains/example.ch/dnssec/ds",
+			status:     http.StatusOK,
+			call: func(c *Client) error {
+				_, err := c.AddDSRecord(context.Background(), "example.ch", DSRecordInput{KeyTag: 1, Algorithm: 13, DigestType: 2, Digest: "ab"})
+				return err
+			},
+		},
+		{
+			name:       "remove ds",
+			wantMethod: http.MethodDelete,
+			wantPath:   "/2/domains/domains/example.ch/dnssec/ds/9",
+			status:     http.StatusNoContent,
+			call: func(c *Client) error {
+				return c.RemoveDSRecord(context.Background(), "example.ch", 9)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("path = %q, want %q", r.URL.Path, tt.wantPath)
+				}
+				if r.Method != tt.wantMethod {
+					t.Errorf("method = %q, want %q", r.Method, tt.wantMethod)
+				}
+				w.WriteHeader(tt.status)
+				if tt.status != http.StatusNoContent {
+					_ = json.NewEncoder(w).Encode(Response[DSRecord]{Result: "success", Data: DSRecord{ID: 9}})
+				}
+			}))
+			t.Cleanup(srv.Close)
+
+			c := New("tok", WithBaseURL(srv.URL))
+			if err := tt.call(c); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
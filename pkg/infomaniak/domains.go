@@ -0,0 +1,204 @@
+package infomaniak
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+)
+
+// ListDomains returns all domains accessible by the current API token.
+func (c *Client) ListDomains(ctx context.Context) ([]Domain, error) {
+	domains, err := collect(c.IterDomains(ctx, ListOptions{}))
+	if err != nil {
+		return nil, fmt.Errorf("list domains: %w", err)
+	}
+
+	return domains, nil
+}
+
+// IterDomains iterates over the domains accessible by the current API token,
+// fetching pages on demand. When the client has an account ID, only that
+// account's domains are returned.
+func (c *Client) IterDomains(ctx context.Context, opts ListOptions) iter.Seq2[Domain, error] {
+	path := "/2/domains/domains"
+	if c.accountID != "" {
+		path += "?account_id=" + url.QueryEscape(c.accountID)
+	}
+	return paginate[Domain](ctx, c, path, opts)
+}
+
+// ShowDomain returns details for a single domain.
+func (c *Client) ShowDomain(ctx context.Context, domain string) (*Domain, error) {
+	path := fmt.Sprintf("/2/domains/domains/%s", domain)
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("show domain %s: %w", domain, err)
+	}
+
+	result, err := decodeResponse[Domain](resp)
+	if err != nil {
+		return nil, fmt.Errorf("show domain %s: %w", domain, err)
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateNameservers sets the nameservers for a domain.
+func (c *Client) UpdateNameservers(ctx context.Context, domain string, input UpdateNameserversInput) error {
+	path := fmt.Sprintf("/2/domains/domains/%s/nameservers", domain)
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("marshal nameserver update for %s: %w", domain, err)
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("update nameservers for %s: %w", domain, err)
+	}
+
+	if err := decodeEmptyResponse(resp); err != nil {
+		return fmt.Errorf("update nameservers for %s: %w", domain, err)
+	}
+
+	return nil
+}
+
+// CheckAvailability reports whether name can be registered and at what
+// price.
+func (c *Client) CheckAvailability(ctx context.Context, name string) (*Availability, error) {
+	query := url.Values{"domain": {name}}
+	if c.accountID != "" {
+		query.Set("account_id", c.accountID)
+	}
+	path := "/2/domains/availability?" + query.Encode()
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("check availability of %s: %w", name, err)
+	}
+
+	result, err := decodeResponse[Availability](resp)
+	if err != nil {
+		return nil, fmt.Errorf("check availability of %s: %w", name, err)
+	}
+
+	if result.Data.Name == "" {
+		result.Data.Name = name
+	}
+	return &result.Data, nil
+}
+
+// RegisterDomain registers a new domain. The account is charged for the
+// registration.
+func (c *Client) RegisterDomain(ctx context.Context, input RegisterDomainInput) (*Domain, error) {
+	path := "/2/domains/domains"
+	if c.accountID != "" {
+		path += "?account_id=" + url.QueryEscape(c.accountID)
+	}
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("marshal registration for %s: %w", input.Name, err)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("register domain %s: %w", input.Name, err)
+	}
+
+	result, err := decodeResponse[Domain](resp)
+	if err != nil {
+		return nil, fmt.Errorf("register domain %s: %w", input.Name, err)
+	}
+
+	return &result.Data, nil
+}
+
+// RenewalPrice returns the price of renewing a domain for the given number
+// of years.
+func (c *Client) RenewalPrice(ctx context.Context, domain string, years int) (*Price, error) {
+	path := fmt.Sprintf("/2/domains/domains/%s/renew?years=%d", domain, years)
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("renewal price for %s: %w", domain, err)
+	}
+
+	result, err := decodeResponse[Price](resp)
+	if err != nil {
+		return nil, fmt.Errorf("renewal price for %s: %w", domain, err)
+	}
+
+	return &result.Data, nil
+}
+
+// RenewDomain extends the registration of a domain. The account is charged
+// for the renewal.
+func (c *Client) RenewDomain(ctx context.Context, domain string, input RenewDomainInput) (*Domain, error) {
+	path := fmt.Sprintf("/2/domains/domains/%s/renew", domain)
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("marshal renewal for %s: %w", domain, err)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("renew domain %s: %w", domain, err)
+	}
+
+	result, err := decodeResponse[Domain](resp)
+	if err != nil {
+		return nil, fmt.Errorf("renew domain %s: %w", domain, err)
+	}
+
+	return &result.Data, nil
+}
+
+// SetAutoRenew turns automatic renewal of a domain on or off.
+func (c *Client) SetAutoRenew(ctx context.Context, domain string, enabled bool) error {
+	path := fmt.Sprintf("/2/domains/domains/%s/auto_renew", domain)
+
+	body, err := json.Marshal(AutoRenewInput{AutoRenew: enabled})
+	if err != nil {
+		return fmt.Errorf("marshal auto-renew for %s: %w", domain, err)
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("set auto-renew for %s: %w", domain, err)
+	}
+
+	if err := decodeEmptyResponse(resp); err != nil {
+		return fmt.Errorf("set auto-renew for %s: %w", domain, err)
+	}
+
+	return nil
+}
+
+// UpdateOptions changes the options of a domain. Only the non-nil fields of
+// input are sent.
+func (c *Client) UpdateOptions(ctx context.Context, domain string, input OptionsInput) error {
+	path := fmt.Sprintf("/2/domains/domains/%s/options", domain)
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("marshal options for %s: %w", domain, err)
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("update options for %s: %w", domain, err)
+	}
+
+	if err := decodeEmptyResponse(resp); err != nil {
+		return fmt.Errorf("update options for %s: %w", domain, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,415 @@
+package infomaniak
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListDomains(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		response Response[[]Domain]
+		status   int
+		wantLen  int
+		wantErr  bool
+	}{
+		{
+			name:   "success with domains",
+			status: http.StatusOK,
+			response: Response[[]Domain]{
+				Result: "success",
+				Data: []Domain{
+					{Name: "example.ch", TLD: "ch"},
+					{Name: "test.com", TLD: "com"},
+				},
+			},
+			wantLen: 2,
+		},
+		{
+			name:     "empty list",
+			status:   http.StatusOK,
+			response: Response[[]Domain]{Result: "success", Data: []Domain{}},
+			wantLen:  0,
+		},
+		{
+			name:   "api error",
+			status: http.StatusForbidden,
+			response: Response[[]Domain]{
+				Result: "error",
+				Error:  &ErrorBody{Code: "forbidden", Description: "access denied"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/2/domains/domains" {
+					t.Errorf("path = %q, want %q", r.URL.Path, "/2/domains/domains")
+				}
+				if r.Method != http.MethodGet {
+					t.Errorf("method = %q, want GET", r.Method)
+				}
+				w.WriteHeader(tt.status)
+				_ = json.NewEncoder(w).Encode(tt.response)
+			}))
+			t.Cleanup(srv.Close)
+
+			c := New("tok", WithBaseURL(srv.URL))
+			domains, err := c.ListDomains(context.Background())
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(domains) != tt.wantLen {
+				t.Errorf("got %d domains, want %d", len(domains), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestShowDomain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		domain   string
+		response Response[Domain]
+		status   int
+		wantName string
+		wantErr  bool
+	}{
+		{
+			name:   "success",
+			domain: "example.ch",
+			status: http.StatusOK,
+			response: Response[Domain]{
+				Result: "success",
+				Data:   Domain{Name: "example.ch", TLD: "ch", ExpiresAt: 1734444000},
+			},
+			wantName: "example.ch",
+		},
+		{
+			name:   "not found",
+			domain: "nope.ch",
+			status: http.StatusNotFound,
+			response: Response[Domain]{
+				Result: "error",
+				Error:  &ErrorBody{Code: "object_not_found", Description: "Object not found"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				wantPath := "/2/domains/domains/" + tt.domain
+				if r.URL.Path != wantPath {
+					t.Errorf("path = %q, want %q", r.URL.Path, wantPath)
+				}
+				w.WriteHeader(tt.status)
+				_ = json.NewEncoder(w).Encode(tt.response)
+			}))
+			t.Cleanup(srv.Close)
+
+			c := New("tok", WithBaseURL(srv.URL))
+			domain, err := c.ShowDomain(context.Background(), tt.domain)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if domain.Name != tt.wantName {
+				t.Errorf("name = %q, want %q", domain.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestUpdateNameservers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		domain   string
+		input    UpdateNameserversInput
+		response Response[any]
+		status   int
+		wantErr  bool
+	}{
+		{
+			name:   "success",
+			domain: "example.ch",
+			input: UpdateNameserversInput{
+				Nameservers:          []string{"ns1.example.ch", "ns2.example.ch"},
+				VerifyNSAvailability: false,
+			},
+			status:   http.StatusOK,
+			response: Response[any]{Result: "success"},
+		},
+		{
+			name:   "validation error",
+			domain: "example.ch",
+			input: UpdateNameserversInput{
+				Nameservers: []string{"invalid"},
+			},
+			status: http.StatusUnprocessableEntity,
+			response: Response[any]{
+				Result: "error",
+				Error:  &ErrorBody{Code: "validation_failed", Description: "invalid nameserver"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				wantPath := "/2/domains/domains/" + tt.domain + "/nameservers"
+				if r.URL.Path != wantPath {
+					t.Errorf("path = %q, want %q", r.URL.Path, wantPath)
+				}
+				if r.Method != http.MethodPut {
+					t.Errorf("method = %q, want PUT", r.Method)
+				}
+
+				var body UpdateNameserversInput
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("decode request body: %v", err)
+				}
+				if len(body.Nameservers) != len(tt.input.Nameservers) {
+					t.Errorf("nameservers count = %d, want %d", len(body.Nameservers), len(tt.input.Nameservers))
+				}
+
+				w.WriteHeader(tt.status)
+				_ = json.NewEncoder(w).Encode(tt.response)
+			}))
+			t.Cleanup(srv.Close)
+
+			c := New("tok", WithBaseURL(srv.URL))
+			err := c.UpdateNameservers(context.Background(), tt.domain, tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestListDomainsAccountID(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("account_id"); got != "12345" {
+			t.Errorf("account_id = %q, want %q", got, "12345")
+		}
+		if got := r.URL.Query().Get("page"); got != "1" {
+			t.Errorf("page = %q, want %q", got, "1")
+		}
+		_ = json.NewEncoder(w).Encode(Response[[]Domain]{Result: "success", Data: []Domain{{Name: "example.ch"}}})
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("tok", WithBaseURL(srv.URL), WithAccountID("12345"))
+	domains, err := c.ListDomains(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(domains) != 1 {
+		t.Errorf("got %d domains, want 1", len(domains))
+	}
+}
+
+func TestCheckAvailability(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2/domains/availability" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/2/domains/availability")
+		}
+		if got := r.URL.Query().Get("domain"); got != "example.ch" {
+			t.Errorf("domain = %q, want %q", got, "example.ch")
+		}
+		_ = json.NewEncoder(w).Encode(Response[Availability]{
+			Result: "success",
+			Data:   Availability{Available: true, Price: &Price{Amount: 11.5, Currency: "CHF"}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("tok", WithBaseURL(srv.URL))
+	got, err := c.CheckAvailability(context.Background(), "example.ch")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Name != "example.ch" || !got.Available || got.Price == nil || got.Price.Amount != 11.5 {
+		t.Errorf("availability = %+v", got)
+	}
+}
+
+func TestRegisterDomain(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2/domains/domains" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/2/domains/domains")
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %q, want POST", r.Method)
+		}
+
+		var body RegisterDomainInput
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		if body.Name != "example.ch" || body.Years != 2 || body.Contacts.Owner != 7 {
+			t.Errorf("body = %+v", body)
+		}
+
+		_ = json.NewEncoder(w).Encode(Response[Domain]{Result: "success", Data: Domain{Name: "example.ch"}})
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("tok", WithBaseURL(srv.URL))
+	domain, err := c.RegisterDomain(context.Background(), RegisterDomainInput{
+		Name:     "example.ch",
+		Years:    2,
+		Contacts: SetContactsInput{Owner: 7, Admin: 7, Tech: 7, Billing: 7},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if domain.Name != "example.ch" {
+		t.Errorf("name = %q, want %q", domain.Name, "example.ch")
+	}
+}
+
+func TestRenewDomain(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2/domains/domains/example.ch/renew" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/2/domains/domains/example.ch/renew")
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			if got := r.URL.Query().Get("years"); got != "3" {
+				t.Errorf("years = %q, want %q", got, "3")
+			}
+			_ = json.NewEncoder(w).Encode(Response[Price]{Result: "success", Data: Price{Amount: 34.5, Currency: "CHF"}})
+		case http.MethodPost:
+			var body RenewDomainInput
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode request body: %v", err)
+			}
+			if body.Years != 3 {
+				t.Errorf("years = %d, want 3", body.Years)
+			}
+			_ = json.NewEncoder(w).Encode(Response[Domain]{Result: "success", Data: Domain{Name: "example.ch", ExpiresAt: 1900000000}})
+		default:
+			t.Errorf("unexpected method %q", r.Method)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("tok", WithBaseURL(srv.URL))
+
+	price, err := c.RenewalPrice(context.Background(), "example.ch", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if price.Amount != 34.5 || price.Currency != "CHF" {
+		t.Errorf("price = %+v", price)
+	}
+
+	domain, err := c.RenewDomain(context.Background(), "example.ch", RenewDomainInput{Years: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if domain.ExpiresAt != 1900000000 {
+		t.Errorf("expires_at = %d, want 1900000000", domain.ExpiresAt)
+	}
+}
+
+func TestSetAutoRenew(t *testing.T) {
+	t.Parallel()
+
+	for _, enabled := range []bool{true, false} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut {
+				t.Errorf("method = %q, want PUT", r.Method)
+			}
+			var body AutoRenewInput
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode request body: %v", err)
+			}
+			if body.AutoRenew != enabled {
+				t.Errorf("auto_renew = %v, want %v", body.AutoRenew, enabled)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		c := New("tok", WithBaseURL(srv.URL))
+		if err := c.SetAutoRenew(context.Background(), "example.ch", enabled); err != nil {
+			t.Errorf("SetAutoRenew(%v): %v", enabled, err)
+		}
+		srv.Close()
+	}
+}
+
+func TestUpdateOptions(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2/domains/domains/example.ch/options" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/2/domains/domains/example.ch/options")
+		}
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %q, want PUT", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if want := `{"dns_anycast":false,"domain_privacy":true}`; string(body) != want {
+			t.Errorf("body = %s, want %s", body, want)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	on, off := true, false
+	c := New("tok", WithBaseURL(srv.URL))
+	if err := c.UpdateOptions(context.Background(), "example.ch", OptionsInput{DomainPrivacy: &on, DNSAnycast: &off}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
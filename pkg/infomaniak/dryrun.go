@@ -0,0 +1,45 @@
+package infomaniak
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrDryRun is returned instead of sending a write request when the client
+// runs in dry-run mode. The request has been described to the dry-run
+// writer.
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// isRead reports whether method only reads state, so it is sent even in
+// dry-run mode.
+func isRead(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// describeRequest writes the request that would be sent to w, with the
+// token and secret body fields redacted. It is written with a single call
+// so that descriptions of concurrent requests do not interleave.
+func (c *Client) describeRequest(w io.Writer, method, path string, payload []byte) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "DRY RUN: %s %s%s\n", method, c.baseURL, path)
+	b.WriteString("Authorization: Bearer <redacted>\n")
+	b.WriteString("Content-Type: application/json\n")
+
+	if len(payload) > 0 {
+		b.WriteString("\n")
+		b.Write(redactBody(payload))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
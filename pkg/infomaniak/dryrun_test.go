@@ -0,0 +1,64 @@
+package infomaniak
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	t.Parallel()
+
+	var writes atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writes.Add(1)
+		}
+		_ = json.NewEncoder(w).Encode(Response[Domain]{Result: "success", Data: Domain{Name: "example.ch"}})
+	}))
+	t.Cleanup(srv.Close)
+
+	var out strings.Builder
+	c := New("secret-token", WithBaseURL(srv.URL), WithDryRun(&out))
+
+	if _, err := c.ShowDomain(context.Background(), "example.ch"); err != nil {
+		t.Fatalf("reads must still be sent: %v", err)
+	}
+
+	err := c.UpdateNameservers(context.Background(), "example.ch", UpdateNameserversInput{Nameservers: []string{"ns1.example.net"}})
+	if !errors.Is(err, ErrDryRun) {
+		t.Fatalf("err = %v, want ErrDryRun", err)
+	}
+
+	_, err = c.TransferIn(context.Background(), TransferInInput{Name: "example.com", AuthCode: "hunter2"})
+	if !errors.Is(err, ErrDryRun) {
+		t.Fatalf("err = %v, want ErrDryRun", err)
+	}
+
+	if n := writes.Load(); n != 0 {
+		t.Errorf("server received %d write requests, want 0", n)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"DRY RUN: PUT " + srv.URL + "/2/domains/domains/example.ch/nameservers",
+		"Authorization: Bearer <redacted>",
+		`"ns1.example.net"`,
+		"DRY RUN: POST " + srv.URL + "/2/domains/transfers",
+		`"auth_code": "<redacted>"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q:\n%s", want, got)
+		}
+	}
+	for _, secret := range []string{"secret-token", "hunter2"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("output leaks %q:\n%s", secret, got)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package infomaniak
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error is returned for requests the API answered with an error. Use
+// errors.As to access it through the wrapping added by Client methods.
+type Error struct {
+	StatusCode  int
+	Code        string
+	Description string
+	Details     []ErrorDetail
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("api error (status %d): %s", e.StatusCode, e.Description)
+	}
+	return fmt.Sprintf("api error %s (status %d): %s", e.Code, e.StatusCode, e.Description)
+}
+
+func newError(status int, body *ErrorBody) *Error {
+	if body == nil {
+		return &Error{StatusCode: status, Description: "unknown error"}
+	}
+	return &Error{
+		StatusCode:  status,
+		Code:        body.Code,
+		Description: body.Description,
+		Details:     body.Errors,
+	}
+}
+
+// IsNotFound reports whether err is an API error for a missing object.
+func IsNotFound(err error) bool {
+	return matchError(err, func(e *Error) bool {
+		return e.StatusCode == http.StatusNotFound || e.Code == "object_not_found" || e.Code == "not_found"
+	})
+}
+
+// IsUnauthorized reports whether err is an API error caused by a missing,
+// invalid or insufficiently scoped token.
+func IsUnauthorized(err error) bool {
+	return matchError(err, func(e *Error) bool {
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	})
+}
+
+// IsRateLimited reports whether err is an API error caused by exceeding the
+// request quota.
+func IsRateLimited(err error) bool {
+	return matchError(err, func(e *Error) bool {
+		return e.StatusCode == http.StatusTooManyRequests || e.Code == "too_many_requests"
+	})
+}
+
+// IsValidation reports whether err is an API error rejecting the request
+// input. The per-field problems are available in Error.Details.
+func IsValidation(err error) bool {
+	return matchError(err, func(e *Error) bool {
+		return e.StatusCode == http.StatusUnprocessableEntity || e.Code == "validation_failed" || len(e.Details) > 0
+	})
+}
+
+func matchError(err error, fn func(*Error) bool) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && fn(apiErr)
+}
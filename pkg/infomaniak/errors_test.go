@@ -0,0 +1,118 @@
+package infomaniak
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorHelpers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		err              error
+		wantNotFound     bool
+		wantUnauthorized bool
+		wantRateLimited  bool
+		wantValidation   bool
+	}{
+		{
+			name:         "not found",
+			err:          &Error{StatusCode: http.StatusNotFound, Code: "object_not_found"},
+			wantNotFound: true,
+		},
+		{
+			name:             "unauthorized",
+			err:              &Error{StatusCode: http.StatusUnauthorized, Code: "not_authorized"},
+			wantUnauthorized: true,
+		},
+		{
+			name:             "forbidden",
+			err:              &Error{StatusCode: http.StatusForbidden},
+			wantUnauthorized: true,
+		},
+		{
+			name:            "rate limited",
+			err:             &Error{StatusCode: http.StatusTooManyRequests},
+			wantRateLimited: true,
+		},
+		{
+			name: "validation",
+			err: &Error{
+				StatusCode: http.StatusUnprocessableEntity,
+				Code:       "validation_failed",
+				Details:    []ErrorDetail{{Code: "invalid", Description: "bad nameserver"}},
+			},
+			wantValidation: true,
+		},
+		{
+			name:         "wrapped",
+			err:          fmt.Errorf("show domain: %w", &Error{StatusCode: http.StatusNotFound}),
+			wantNotFound: true,
+		},
+		{
+			name: "not an api error",
+			err:  errors.New("boom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsNotFound(tt.err); got != tt.wantNotFound {
+				t.Errorf("IsNotFound = %v, want %v", got, tt.wantNotFound)
+			}
+			if got := IsUnauthorized(tt.err); got != tt.wantUnauthorized {
+				t.Errorf("IsUnauthorized = %v, want %v", got, tt.wantUnauthorized)
+			}
+			if got := IsRateLimited(tt.err); got != tt.wantRateLimited {
+				t.Errorf("IsRateLimited = %v, want %v", got, tt.wantRateLimited)
+			}
+			if got := IsValidation(tt.err); got != tt.wantValidation {
+				t.Errorf("IsValidation = %v, want %v", got, tt.wantValidation)
+			}
+		})
+	}
+}
+
+func TestClientReturnsTypedError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(Response[any]{
+			Result: "error",
+			Error: &ErrorBody{
+				Code:        "validation_failed",
+				Description: "Validation failed",
+				Errors: []ErrorDetail{
+					{Code: "invalid_nameserver", Description: "ns1 is not resolvable", Context: map[string]string{"attribute": "nameservers.0"}},
+				},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("tok", WithBaseURL(srv.URL))
+	err := c.UpdateNameservers(context.Background(), "example.ch", UpdateNameserversInput{Nameservers: []string{"ns1"}})
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *infomaniak.Error", err)
+	}
+	if apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", apiErr.StatusCode, http.StatusUnprocessableEntity)
+	}
+	if len(apiErr.Details) != 1 || apiErr.Details[0].Context["attribute"] != "nameservers.0" {
+		t.Errorf("details = %+v, want the nameservers.0 validation error", apiErr.Details)
+	}
+	if !IsValidation(err) {
+		t.Error("IsValidation = false, want true")
+	}
+}
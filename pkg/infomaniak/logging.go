@@ -0,0 +1,97 @@
+package infomaniak
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// maxLoggedBody is how much of a body LoggingTransport logs.
+const maxLoggedBody = 16 << 10
+
+// LoggingTransport is an http.RoundTripper that logs every request sent
+// through it, including retries, with its status and duration. The
+// Authorization header and secret body fields are always redacted.
+type LoggingTransport struct {
+	// Next sends the requests. Nil uses http.DefaultTransport.
+	Next http.RoundTripper
+	// Logger receives the log records. Requests and responses are logged
+	// at Info; headers and bodies are included when the logger has Debug
+	// enabled.
+	Logger *slog.Logger
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	debug := t.Logger.Enabled(ctx, slog.LevelDebug)
+
+	attrs := []any{"method", req.Method, "url", req.URL.Redacted()}
+	if debug {
+		attrs = append(attrs, "headers", redactHeader(req.Header))
+		if body := requestBody(req); len(body) > 0 {
+			attrs = append(attrs, "body", loggedBody(body))
+		}
+	}
+	t.Logger.InfoContext(ctx, "http request", attrs...)
+
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	if err != nil {
+		t.Logger.WarnContext(ctx, "http error", "method", req.Method, "url", req.URL.Redacted(),
+			"duration", elapsed, "error", err)
+		return nil, err
+	}
+
+	attrs = []any{"method", req.Method, "url", req.URL.Redacted(), "status", resp.StatusCode, "duration", elapsed}
+	if debug {
+		attrs = append(attrs, "headers", redactHeader(resp.Header))
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if readErr != nil {
+			attrs = append(attrs, "body_error", readErr)
+		} else if len(body) > 0 {
+			attrs = append(attrs, "body", loggedBody(body))
+		}
+	}
+	t.Logger.InfoContext(ctx, "http response", attrs...)
+
+	return resp, nil
+}
+
+// requestBody returns a copy of the request body without consuming it.
+func requestBody(req *http.Request) []byte {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// loggedBody redacts a body and cuts it to maxLoggedBody.
+func loggedBody(body []byte) string {
+	body = redactCompact(body)
+	if len(body) > maxLoggedBody {
+		return string(body[:maxLoggedBody]) + "... (truncated)"
+	}
+	return string(body)
+}
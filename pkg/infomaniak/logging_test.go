@@ -0,0 +1,86 @@
+package infomaniak
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response[AuthCode]{Result: "success", Data: AuthCode{AuthCode: "response-secret"}})
+	}))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name     string
+		level    slog.Level
+		want     []string
+		wantNot  []string
+		wantBody bool
+	}{
+		{
+			name:    "info",
+			level:   slog.LevelInfo,
+			want:    []string{`msg="http request" method=POST`, `msg="http response" method=POST`, "status=200", "duration="},
+			wantNot: []string{"body=", "headers="},
+		},
+		{
+			name:  "debug",
+			level: slog.LevelDebug,
+			want: []string{
+				"Authorization:[Bearer <redacted>]",
+				`\"auth_code\":\"<redacted>\"`,
+				`\"name\":\"example.com\"`,
+				"body=",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out strings.Builder
+			logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: tt.level}))
+			c := New("secret-token", WithBaseURL(srv.URL), WithLogger(logger))
+
+			if _, err := c.TransferIn(context.Background(), TransferInInput{Name: "example.com", AuthCode: "hunter2"}); err != nil {
+				t.Fatalf("transfer in: %v", err)
+			}
+
+			got := out.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("log does not contain %q:\n%s", want, got)
+				}
+			}
+			for _, secret := range append([]string{"secret-token", "hunter2", "response-secret"}, tt.wantNot...) {
+				if strings.Contains(got, secret) {
+					t.Errorf("log contains %q:\n%s", secret, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	t.Parallel()
+
+	got := string(redactCompact([]byte(`{"data":{"items":[{"api_token":"a","name":"x"}],"Password":"b"},"auth_code":"c","n":12345678901234567890}`)))
+	want := `{"auth_code":"<redacted>","data":{"Password":"<redacted>","items":[{"api_token":"<redacted>","name":"x"}]},"n":12345678901234567890}`
+	if got != want {
+		t.Errorf("redactCompact =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := string(redactBody([]byte("not json"))); got != "not json" {
+		t.Errorf("redactBody(not json) = %q", got)
+	}
+}
@@ -0,0 +1,140 @@
+package infomaniak
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created by New.
+type Option func(*clientOptions)
+
+// Middleware wraps the transport requests are sent through, for example to
+// add headers, record metrics or log traffic.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+type clientOptions struct {
+	baseURL    string
+	accountID  string
+	userAgent  string
+	httpClient *http.Client
+	transport  http.RoundTripper
+	middleware []Middleware
+	timeout    time.Duration
+	retry      RetryPolicy
+	rateLimit  float64
+	rateBurst  int
+	dryRun     io.Writer
+	audit      func(AuditEntry)
+}
+
+// buildHTTPClient returns the http.Client the Client sends requests with.
+// A client given with WithHTTPClient is copied, never modified.
+func (o *clientOptions) buildHTTPClient() *http.Client {
+	hc := &http.Client{Timeout: DefaultTimeout}
+	if o.httpClient != nil {
+		copied := *o.httpClient
+		hc = &copied
+	}
+	if o.timeout > 0 {
+		hc.Timeout = o.timeout
+	}
+
+	rt := hc.Transport
+	if o.transport != nil {
+		rt = o.transport
+	}
+	if rt == nil && len(o.middleware) > 0 {
+		rt = http.DefaultTransport
+	}
+	// The first middleware is the outermost, so it sees requests first.
+	for i := len(o.middleware) - 1; i >= 0; i-- {
+		rt = o.middleware[i](rt)
+	}
+	hc.Transport = rt
+
+	return hc
+}
+
+// WithBaseURL sends requests to url instead of DefaultBaseURL, for example a
+// test server.
+func WithBaseURL(url string) Option {
+	return func(o *clientOptions) {
+		if url != "" {
+			o.baseURL = url
+		}
+	}
+}
+
+// WithAccountID restricts account-scoped listings to a single account.
+func WithAccountID(id string) Option {
+	return func(o *clientOptions) { o.accountID = id }
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(o *clientOptions) {
+		if ua != "" {
+			o.userAgent = ua
+		}
+	}
+}
+
+// WithHTTPClient sends requests with a copy of hc, keeping its transport,
+// timeout, cookie jar and redirect policy.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(o *clientOptions) { o.httpClient = hc }
+}
+
+// WithTransport replaces the transport of the HTTP client. Middleware is
+// applied on top of it.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(o *clientOptions) { o.transport = rt }
+}
+
+// WithMiddleware wraps the transport in mw. When given several times or
+// with several arguments, the first middleware is the outermost.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(o *clientOptions) { o.middleware = append(o.middleware, mw...) }
+}
+
+// WithLogger logs every HTTP request to logger through a LoggingTransport.
+func WithLogger(logger *slog.Logger) Option {
+	return WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return &LoggingTransport{Next: next, Logger: logger}
+	})
+}
+
+// WithTimeout bounds each HTTP request, including reading the response.
+// Retries get a fresh timeout each.
+func WithTimeout(d time.Duration) Option {
+	return func(o *clientOptions) { o.timeout = d }
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *clientOptions) { o.retry = p }
+}
+
+// WithRateLimit caps the number of requests per second across all calls
+// made through the client, including retries. burst is the number of
+// requests allowed back to back; values below 1 mean 1.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(o *clientOptions) {
+		o.rateLimit = perSecond
+		o.rateBurst = burst
+	}
+}
+
+// WithDryRun describes every write request to w instead of sending it; such
+// requests fail with ErrDryRun. Reads are still sent.
+func WithDryRun(w io.Writer) Option {
+	return func(o *clientOptions) { o.dryRun = w }
+}
+
+// WithAudit calls fn once for every write request that was sent, after its
+// final attempt. Dry-run requests are not reported.
+func WithAudit(fn func(AuditEntry)) Option {
+	return func(o *clientOptions) { o.audit = fn }
+}
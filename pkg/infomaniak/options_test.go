@@ -0,0 +1,147 @@
+package infomaniak
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestWithUserAgent(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("tok", WithBaseURL(srv.URL), WithUserAgent("my-controller/1.2"))
+	resp, err := c.doRequest(context.Background(), http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if got != "my-controller/1.2" {
+		t.Errorf("User-Agent = %q, want %q", got, "my-controller/1.2")
+	}
+}
+
+func TestWithMiddleware(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":"success","data":"` + r.Header.Get("X-Trace") + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	tag := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				mu.Lock()
+				order = append(order, name)
+				mu.Unlock()
+				r = r.Clone(r.Context())
+				r.Header.Set("X-Trace", strings.TrimPrefix(r.Header.Get("X-Trace")+","+name, ","))
+				return next.RoundTrip(r)
+			})
+		}
+	}
+
+	c := New("tok", WithBaseURL(srv.URL), WithMiddleware(tag("outer"), tag("middle")), WithMiddleware(tag("inner")))
+	resp, err := c.doRequest(context.Background(), http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := decodeResponse[string](resp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "outer,middle,inner"; got.Data != want {
+		t.Errorf("server saw X-Trace %q, want %q", got.Data, want)
+	}
+	if want := "outer,middle,inner"; strings.Join(order, ",") != want {
+		t.Errorf("middleware ran in order %q, want %q", order, want)
+	}
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	var sent bool
+	hc := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			sent = true
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       http.NoBody,
+				Request:    r,
+			}, nil
+		}),
+	}
+	c := New("tok", WithHTTPClient(hc), WithTimeout(time.Second), WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return next
+	}))
+
+	resp, err := c.doRequest(context.Background(), http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if !sent {
+		t.Error("request was not sent through the client's transport")
+	}
+	if c.httpClient == hc {
+		t.Error("the given http.Client is used directly, want a copy")
+	}
+	if c.httpClient.Timeout != time.Second {
+		t.Errorf("timeout = %v, want %v", c.httpClient.Timeout, time.Second)
+	}
+	if hc.Timeout != 5*time.Second {
+		t.Errorf("the given http.Client was modified: timeout = %v", hc.Timeout)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts []Option
+		want time.Duration
+	}{
+		{name: "default", want: DefaultTimeout},
+		{name: "custom", opts: []Option{WithTimeout(time.Minute)}, want: time.Minute},
+		{name: "from http client", opts: []Option{WithHTTPClient(&http.Client{Timeout: time.Hour})}, want: time.Hour},
+		{name: "no timeout", opts: []Option{WithHTTPClient(&http.Client{})}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := New("tok", tt.opts...)
+			if c.httpClient.Timeout != tt.want {
+				t.Errorf("timeout = %v, want %v", c.httpClient.Timeout, tt.want)
+			}
+		})
+	}
+}
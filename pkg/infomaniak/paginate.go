@@ -0,0 +1,90 @@
+package infomaniak
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultPerPage is the page size requested when ListOptions.PerPage is zero.
+const DefaultPerPage = 100
+
+// ListOptions controls which part of a paginated list is fetched.
+type ListOptions struct {
+	// Page fetches only this page (starting at 1). Zero walks all pages.
+	Page int
+	// PerPage is the number of items requested per page.
+	PerPage int
+	// Limit stops iteration after this many items. Zero means no limit.
+	Limit int
+}
+
+// paginate iterates over the items of a list endpoint, requesting further
+// pages as the consumer advances. Responses without pagination metadata are
+// treated as a single page. Iteration ends after the first error.
+func paginate[T any](ctx context.Context, c *Client, path string, opts ListOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		perPage := opts.PerPage
+		if perPage <= 0 {
+			perPage = DefaultPerPage
+		}
+
+		page := max(opts.Page, 1)
+		seen := 0
+		for {
+			q := url.Values{}
+			q.Set("page", strconv.Itoa(page))
+			q.Set("per_page", strconv.Itoa(perPage))
+
+			sep := "?"
+			if strings.Contains(path, "?") {
+				sep = "&"
+			}
+
+			result, err := fetchPage[T](ctx, c, path+sep+q.Encode())
+			if err != nil {
+				var zero T
+				yield(zero, fmt.Errorf("page %d: %w", page, err))
+				return
+			}
+
+			for _, item := range result.Data {
+				if !yield(item, nil) {
+					return
+				}
+				seen++
+				if opts.Limit > 0 && seen >= opts.Limit {
+					return
+				}
+			}
+
+			if opts.Page > 0 || len(result.Data) == 0 || page >= result.Pages {
+				return
+			}
+			page++
+		}
+	}
+}
+
+func fetchPage[T any](ctx context.Context, c *Client, path string) (*Response[[]T], error) {
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResponse[[]T](resp)
+}
+
+// collect drains a paginated iterator into a slice.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	items := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
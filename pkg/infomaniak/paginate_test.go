@@ -0,0 +1,165 @@
+package infomaniak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// pagedDomains serves total domains in pages of the requested size and
+// counts the requests it receives.
+func pagedDomains(t *testing.T, total int, failPage int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		if page < 1 || perPage < 1 {
+			t.Errorf("query = %q, want page and per_page", r.URL.RawQuery)
+		}
+		if page == failPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(Response[[]Domain]{
+				Result: "error",
+				Error:  &ErrorBody{Code: "internal", Description: "boom"},
+			})
+			return
+		}
+
+		pages := (total + perPage - 1) / perPage
+		var data []Domain
+		for i := (page - 1) * perPage; i < min(page*perPage, total); i++ {
+			data = append(data, Domain{Name: fmt.Sprintf("d%d.ch", i)})
+		}
+
+		_ = json.NewEncoder(w).Encode(Response[[]Domain]{
+			Result:  "success",
+			Data:    data,
+			Page:    page,
+			Pages:   pages,
+			Total:   total,
+			PerPage: perPage,
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func TestIterDomains(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		total        int
+		opts         ListOptions
+		failPage     int
+		wantNames    []string
+		wantRequests int32
+		wantErr      bool
+	}{
+		{
+			name:         "walks all pages",
+			total:        5,
+			opts:         ListOptions{PerPage: 2},
+			wantNames:    []string{"d0.ch", "d1.ch", "d2.ch", "d3.ch", "d4.ch"},
+			wantRequests: 3,
+		},
+		{
+			name:         "limit stops early",
+			total:        5,
+			opts:         ListOptions{PerPage: 2, Limit: 3},
+			wantNames:    []string{"d0.ch", "d1.ch", "d2.ch"},
+			wantRequests: 2,
+		},
+		{
+			name:         "single page",
+			total:        5,
+			opts:         ListOptions{PerPage: 2, Page: 2},
+			wantNames:    []string{"d2.ch", "d3.ch"},
+			wantRequests: 1,
+		},
+		{
+			name:         "empty account",
+			total:        0,
+			opts:         ListOptions{},
+			wantNames:    nil,
+			wantRequests: 1,
+		},
+		{
+			name:         "error on later page",
+			total:        5,
+			opts:         ListOptions{PerPage: 2},
+			failPage:     2,
+			wantNames:    []string{"d0.ch", "d1.ch"},
+			wantRequests: 2,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv, requests := pagedDomains(t, tt.total, tt.failPage)
+			c := New("tok", WithBaseURL(srv.URL))
+
+			var names []string
+			var gotErr error
+			for d, err := range c.IterDomains(context.Background(), tt.opts) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				names = append(names, d.Name)
+			}
+
+			if tt.wantErr != (gotErr != nil) {
+				t.Fatalf("err = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+			if fmt.Sprint(names) != fmt.Sprint(tt.wantNames) {
+				t.Errorf("names = %v, want %v", names, tt.wantNames)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestListDomainsAllPages(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := pagedDomains(t, 2*DefaultPerPage+1, 0)
+	c := New("tok", WithBaseURL(srv.URL))
+
+	domains, err := c.ListDomains(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(domains) != 2*DefaultPerPage+1 {
+		t.Errorf("got %d domains, want %d", len(domains), 2*DefaultPerPage+1)
+	}
+}
+
+func TestIterDomainsStopsWhenConsumerBreaks(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := pagedDomains(t, 10, 0)
+	c := New("tok", WithBaseURL(srv.URL))
+
+	for range c.IterDomains(context.Background(), ListOptions{PerPage: 2}) {
+		break
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
@@ -0,0 +1,86 @@
+package infomaniak
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+)
+
+// ListRecords returns all DNS records in the zone for a domain.
+func (c *Client) ListRecords(ctx context.Context, domain string) ([]Record, error) {
+	records, err := collect(c.IterRecords(ctx, domain, ListOptions{}))
+	if err != nil {
+		return nil, fmt.Errorf("list records for %s: %w", domain, err)
+	}
+
+	return records, nil
+}
+
+// IterRecords iterates over the DNS records in the zone for a domain,
+// fetching pages on demand.
+func (c *Client) IterRecords(ctx context.Context, domain string, opts ListOptions) iter.Seq2[Record, error] {
+	path := fmt.Sprintf("/2/zones/%s/records", domain)
+	return paginate[Record](ctx, c, path, opts)
+}
+
+// CreateRecord adds a DNS record to the zone for a domain.
+func (c *Client) CreateRecord(ctx context.Context, domain string, input RecordInput) (*Record, error) {
+	path := fmt.Sprintf("/2/zones/%s/records", domain)
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("marshal record for %s: %w", domain, err)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create record for %s: %w", domain, err)
+	}
+
+	result, err := decodeResponse[Record](resp)
+	if err != nil {
+		return nil, fmt.Errorf("create record for %s: %w", domain, err)
+	}
+
+	return &result.Data, nil
+}
+
+// UpdateRecord replaces an existing DNS record in the zone for a domain.
+func (c *Client) UpdateRecord(ctx context.Context, domain string, id int, input RecordInput) (*Record, error) {
+	path := fmt.Sprintf("/2/zones/%s/records/%d", domain, id)
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("marshal record %d for %s: %w", id, domain, err)
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("update record %d for %s: %w", id, domain, err)
+	}
+
+	result, err := decodeResponse[Record](resp)
+	if err != nil {
+		return nil, fmt.Errorf("update record %d for %s: %w", id, domain, err)
+	}
+
+	return &result.Data, nil
+}
+
+// DeleteRecord removes a DNS record from the zone for a domain.
+func (c *Client) DeleteRecord(ctx context.Context, domain string, id int) error {
+	path := fmt.Sprintf("/2/zones/%s/records/%d", domain, id)
+
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return fmt.Errorf("delete record %d for %s: %w", id, domain, err)
+	}
+
+	if err := decodeEmptyResponse(resp); err != nil {
+		return fmt.Errorf("delete record %d for %s: %w", id, domain, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package infomaniak
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListRecords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		domain   string
+		response Response[[]Record]
+		status   int
+		wantLen  int
+		wantErr  bool
+	}{
+		{
+			name:   "success with records",
+			domain: "example.ch",
+			status: http.StatusOK,
+			response: Response[[]Record]{
+				Result: "success",
+				Data: []Record{
+					{ID: 1, Source: "www", Type: "A", TTL: 3600, Target: "192.0.2.1"},
+					{ID: 2, Source: ".", Type: "MX", TTL: 3600, Target: "mx.example.ch", Priority: 10},
+				},
+			},
+			wantLen: 2,
+		},
+		{
+			name:   "zone not found",
+			domain: "nope.ch",
+			status: http.StatusNotFound,
+			response: Response[[]Record]{
+				Result: "error",
+				Error:  &ErrorBody{Code: "object_not_found", Description: "Object not found"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				wantPath := "/2/zones/" + tt.domain + "/records"
+				if r.URL.Path != wantPath {
+					t.Errorf("path = %q, want %q", r.URL.Path, wantPath)
+				}
+				if r.Method != http.MethodGet {
+					t.Errorf("method = %q, want GET", r.Method)
+				}
+				w.WriteHeader(tt.status)
+				_ = json.NewEncoder(w).Encode(tt.response)
+			}))
+			t.Cleanup(srv.Close)
+
+			c := New("tok", WithBaseURL(srv.URL))
+			records, err := c.ListRecords(context.Background(), tt.domain)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(records) != tt.wantLen {
+				t.Errorf("got %d records, want %d", len(records), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestCreateRecord(t *testing.T) {
+	t.Parallel()
+
+	input := RecordInput{Source: "_sip._tcp", Type: "SRV", TTL: 300, Target: "sip.example.ch", Priority: 10, Weight: 5, Port: 5060}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2/zones/example.ch/records" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/2/zones/example.ch/records")
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %q, want POST", r.Method)
+		}
+
+		var body RecordInput
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		if body != input {
+			t.Errorf("body = %+v, want %+v", body, input)
+		}
+
+		_ = json.NewEncoder(w).Encode(Response[Record]{
+			Result: "success",
+			Data: Record{
+				ID: 42, Source: body.Source, Type: body.Type, TTL: body.TTL, Target: body.Target,
+				Priority: body.Priority, Weight: body.Weight, Port: body.Port,
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("tok", WithBaseURL(srv.URL))
+	record, err := c.CreateRecord(context.Background(), "example.ch", input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record.ID != 42 {
+		t.Errorf("id = %d, want 42", record.ID)
+	}
+	if record.Port != 5060 {
+		t.Errorf("port = %d, want 5060", record.Port)
+	}
+}
+
+func TestUpdateRecord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		response Response[Record]
+		status   int
+		wantErr  bool
+	}{
+		{
+			name:     "success",
+			status:   http.StatusOK,
+			response: Response[Record]{Result: "success", Data: Record{ID: 7, Source: "www", Type: "A", Target: "192.0.2.2"}},
+		},
+		{
+			name:   "validation error",
+			status: http.StatusUnprocessableEntity,
+			response: Response[Record]{
+				Result: "error",
+				Error:  &ErrorBody{Code: "validation_failed", Description: "invalid target"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/2/zones/example.ch/records/7" {
+					t.Errorf("path = %q, want %q", r.URL.Path, "/2/zones/example.ch/records/7")
+				}
+				if r.Method != http.MethodPut {
+					t.Errorf("method = %q, want PUT", r.Method)
+				}
+				w.WriteHeader(tt.status)
+				_ = json.NewEncoder(w).Encode(tt.response)
+			}))
+			t.Cleanup(srv.Close)
+
+			c := New("tok", WithBaseURL(srv.URL))
+			record, err := c.UpdateRecord(context.Background(), "example.ch", 7, RecordInput{Source: "www", Type: "A", Target: "192.0.2.2"})
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if record.Target != "192.0.2.2" {
+				t.Errorf("target = %q, want %q", record.Target, "192.0.2.2")
+			}
+		})
+	}
+}
+
+func TestDeleteRecord(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2/zones/example.ch/records/7" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/2/zones/example.ch/records/7")
+		}
+		if r.Method != http.MethodDelete {
+			t.Errorf("method = %q, want DELETE", r.Method)
+		}
+		_ = json.NewEncoder(w).Encode(Response[bool]{Result: "success", Data: true})
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("tok", WithBaseURL(srv.URL))
+	if err := c.DeleteRecord(context.Background(), "example.ch", 7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package infomaniak
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// redactedFields are body fields whose values are never printed or logged,
+// in addition to any field whose name mentions a token, secret or password.
+var redactedFields = map[string]bool{
+	"auth_code": true,
+}
+
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	return redactedFields[name] ||
+		strings.Contains(name, "token") ||
+		strings.Contains(name, "secret") ||
+		strings.Contains(name, "password")
+}
+
+// redactBody indents a JSON body and masks secret fields. Bodies that are
+// not JSON are returned unchanged.
+func redactBody(payload []byte) []byte {
+	v, ok := redactJSON(payload)
+	if !ok {
+		return payload
+	}
+	return encodeJSON(v, "  ", payload)
+}
+
+// redactCompact is redactBody without indentation.
+func redactCompact(payload []byte) []byte {
+	v, ok := redactJSON(payload)
+	if !ok {
+		return payload
+	}
+	return encodeJSON(v, "", payload)
+}
+
+// redactJSON decodes payload and masks secret fields at any depth.
+func redactJSON(payload []byte) (any, bool) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+	return redactValue(v), true
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if isSecretField(k) {
+				v[k] = "<redacted>"
+			} else {
+				v[k] = redactValue(field)
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return v
+}
+
+// encodeJSON re-encodes v, which sorts object keys and keeps the output
+// stable. fallback is returned if encoding fails.
+func encodeJSON(v any, indent string, fallback []byte) []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(v); err != nil {
+		return fallback
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// redactHeader returns a copy of h with credentials masked.
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range []string{"Authorization", "Cookie", "Set-Cookie"} {
+		if h.Get(name) == "" {
+			continue
+		}
+		if name == "Authorization" {
+			scheme, _, _ := strings.Cut(h.Get(name), " ")
+			h.Set(name, scheme+" <redacted>")
+		} else {
+			h.Set(name, "<redacted>")
+		}
+	}
+	return h
+}
@@ -0,0 +1,140 @@
+package infomaniak
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 1 are treated as 1, which disables retries.
+	MaxAttempts int
+	// BaseBackoff is the delay before the first retry; it doubles with every
+	// further attempt.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the computed delay and any server-provided Retry-After.
+	// A request is not retried if the delay would reach the deadline of its
+	// context; the last response or error is returned instead.
+	MaxBackoff time.Duration
+	// Jitter randomizes each delay by up to this fraction (0 to 1) in either
+	// direction so that concurrent clients do not retry in lockstep.
+	Jitter float64
+	// RetryNonIdempotent also retries POST and PATCH requests, which may
+	// apply a change twice if the first attempt reached the server.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is used unless WithRetryPolicy overrides it.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseBackoff: 500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+	Jitter:      0.2,
+}
+
+// retryableStatus reports whether a response status is worth retrying.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryableError reports whether a transport error is likely transient.
+func retryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func (p RetryPolicy) allowsMethod(method string) bool {
+	if p.RetryNonIdempotent {
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before retry number attempt (starting at 1).
+// A delay requested by the server takes precedence over the exponential one.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response, now time.Time) time.Duration {
+	if resp != nil {
+		if d, ok := serverDelay(resp.Header, now); ok {
+			return min(d, p.MaxBackoff)
+		}
+	}
+
+	d := p.BaseBackoff << (attempt - 1)
+	if d <= 0 || d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		spread := float64(d) * p.Jitter
+		d += time.Duration(spread * (2*rand.Float64() - 1))
+	}
+
+	return max(d, 0)
+}
+
+// serverDelay extracts a wait time from Retry-After or, failing that, from
+// the rate-limit reset headers.
+func serverDelay(h http.Header, now time.Time) (time.Duration, bool) {
+	if v := h.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(t.Sub(now), 0), true
+		}
+	}
+
+	if h.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+	reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || reset < 0 {
+		return 0, false
+	}
+	// Reset is either a delta in seconds or a Unix timestamp.
+	if reset > now.Unix()-86400 {
+		return max(time.Unix(reset, 0).Sub(now), 0), true
+	}
+	return time.Duration(reset) * time.Second, true
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
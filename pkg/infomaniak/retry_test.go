@@ -0,0 +1,328 @@
+package infomaniak
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetry keeps the tests quick while still exercising the backoff path.
+var fastRetry = RetryPolicy{
+	MaxAttempts: 3,
+	BaseBackoff: time.Millisecond,
+	MaxBackoff:  10 * time.Millisecond,
+}
+
+func TestDoRequestRetries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		failures     int
+		failStatus   int
+		policy       RetryPolicy
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{
+			name:         "recovers after transient 503s",
+			method:       http.MethodGet,
+			failures:     2,
+			failStatus:   http.StatusServiceUnavailable,
+			policy:       fastRetry,
+			wantAttempts: 3,
+		},
+		{
+			name:         "recovers after 429",
+			method:       http.MethodPut,
+			failures:     1,
+			failStatus:   http.StatusTooManyRequests,
+			policy:       fastRetry,
+			wantAttempts: 2,
+		},
+		{
+			name:         "gives up after max attempts",
+			method:       http.MethodGet,
+			failures:     5,
+			failStatus:   http.StatusBadGateway,
+			policy:       fastRetry,
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "does not retry non-retryable status",
+			method:       http.MethodGet,
+			failures:     1,
+			failStatus:   http.StatusInternalServerError,
+			policy:       fastRetry,
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "does not retry POST by default",
+			method:       http.MethodPost,
+			failures:     1,
+			failStatus:   http.StatusServiceUnavailable,
+			policy:       fastRetry,
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:       "retries POST when allowed",
+			method:     http.MethodPost,
+			failures:   1,
+			failStatus: http.StatusServiceUnavailable,
+			policy: RetryPolicy{
+				MaxAttempts:        3,
+				BaseBackoff:        time.Millisecond,
+				MaxBackoff:         10 * time.Millisecond,
+				RetryNonIdempotent: true,
+			},
+			wantAttempts: 2,
+		},
+		{
+			name:         "retries disabled",
+			method:       http.MethodGet,
+			failures:     1,
+			failStatus:   http.StatusServiceUnavailable,
+			policy:       RetryPolicy{MaxAttempts: 1},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]string
+				if r.Method != http.MethodGet {
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["k"] != "v" {
+						t.Errorf("attempt %d: body not replayed: %v %v", attempts.Load()+1, body, err)
+					}
+				}
+				if int(attempts.Add(1)) <= tt.failures {
+					w.WriteHeader(tt.failStatus)
+					_, _ = w.Write([]byte("<html>busy</html>"))
+					return
+				}
+				_ = json.NewEncoder(w).Encode(Response[string]{Result: "success", Data: "ok"})
+			}))
+			t.Cleanup(srv.Close)
+
+			var payload []byte
+			if tt.method != http.MethodGet {
+				payload = []byte(`{"k":"v"}`)
+			}
+
+			c := New("tok", WithBaseURL(srv.URL), WithRetryPolicy(tt.policy))
+			resp, err := c.doRequest(context.Background(), tt.method, "/flaky", bytesReader(payload))
+			if err == nil {
+				_, err = decodeResponse[string](resp)
+			}
+
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDoRequestRetriesConnectionReset(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("hijack: %v", err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Response[string]{Result: "success", Data: "ok"})
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("tok", WithBaseURL(srv.URL), WithRetryPolicy(fastRetry))
+	resp, err := c.doRequest(context.Background(), http.MethodGet, "/reset", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := decodeResponse[string](resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
+func TestDoRequestRetryRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	c := New("tok", WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  time.Minute,
+	}))
+
+	start := time.Now()
+	_, err := c.doRequest(ctx, http.MethodGet, "/slow", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("waited %v despite cancelled context", elapsed)
+	}
+}
+
+func TestDoRequestRetryStopsBeforeDeadline(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c := New("tok", WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  time.Minute,
+	}))
+
+	start := time.Now()
+	resp, err := c.doRequest(ctx, http.MethodGet, "/slow", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := decodeResponse[string](resp); !IsRateLimited(err) {
+		t.Errorf("err = %v, want the rate limit error of the API", err)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waited %v for a retry that could not finish before the deadline", elapsed)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	p := RetryPolicy{BaseBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	tests := []struct {
+		name    string
+		attempt int
+		header  http.Header
+		want    time.Duration
+	}{
+		{name: "first retry", attempt: 1, want: time.Second},
+		{name: "exponential", attempt: 3, want: 4 * time.Second},
+		{name: "capped", attempt: 10, want: 10 * time.Second},
+		{
+			name:    "retry-after seconds",
+			attempt: 1,
+			header:  http.Header{"Retry-After": {"7"}},
+			want:    7 * time.Second,
+		},
+		{
+			name:    "retry-after date",
+			attempt: 1,
+			header:  http.Header{"Retry-After": {now.Add(3 * time.Second).Format(http.TimeFormat)}},
+			want:    3 * time.Second,
+		},
+		{
+			name:    "retry-after capped",
+			attempt: 1,
+			header:  http.Header{"Retry-After": {"3600"}},
+			want:    10 * time.Second,
+		},
+		{
+			name:    "rate limit reset timestamp",
+			attempt: 1,
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {strconv.FormatInt(now.Add(5*time.Second).Unix(), 10)},
+			},
+			want: 5 * time.Second,
+		},
+		{
+			name:    "rate limit reset delta",
+			attempt: 1,
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {"2"},
+			},
+			want: 2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var resp *http.Response
+			if tt.header != nil {
+				resp = &http.Response{Header: tt.header}
+			}
+			if got := p.backoff(tt.attempt, resp, now); got != tt.want {
+				t.Errorf("backoff = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryBackoffJitter(t *testing.T) {
+	t.Parallel()
+
+	p := RetryPolicy{BaseBackoff: time.Second, MaxBackoff: time.Minute, Jitter: 0.5}
+	for range 100 {
+		got := p.backoff(1, nil, time.Now())
+		if got < 500*time.Millisecond || got > 1500*time.Millisecond {
+			t.Fatalf("backoff = %v, want within 50%% of 1s", got)
+		}
+	}
+}
+
+func bytesReader(b []byte) io.Reader {
+	if b == nil {
+		return nil
+	}
+	return bytes.NewReader(b)
+}
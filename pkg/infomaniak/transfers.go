@@ -0,0 +1,124 @@
+package infomaniak
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// EPP status codes of Domain.Status relevant to transfers (RFC 5731).
+const (
+	StatusClientTransferProhibited = "clientTransferProhibited"
+	StatusPendingTransfer          = "pendingTransfer"
+)
+
+// Transfer states after which a transfer no longer changes.
+const (
+	TransferCompleted = "completed"
+	TransferFailed    = "failed"
+	TransferCancelled = "cancelled"
+)
+
+// TransferLocked reports whether the registrar transfer lock is set.
+func (d *Domain) TransferLocked() bool {
+	return slices.Contains(d.Status, StatusClientTransferProhibited)
+}
+
+// PendingTransfer reports whether a transfer of the domain is in progress.
+func (d *Domain) PendingTransfer() bool {
+	return slices.Contains(d.Status, StatusPendingTransfer)
+}
+
+// Done reports whether the transfer has reached a final state.
+func (t *Transfer) Done() bool {
+	switch t.Status {
+	case TransferCompleted, TransferFailed, TransferCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// TransferIn starts transferring a domain from another registrar.
+func (c *Client) TransferIn(ctx context.Context, input TransferInInput) (*Transfer, error) {
+	path := "/2/domains/transfers"
+	if c.accountID != "" {
+		path += "?account_id=" + url.QueryEscape(c.accountID)
+	}
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("marshal transfer for %s: %w", input.Name, err)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("transfer in %s: %w", input.Name, err)
+	}
+
+	result, err := decodeResponse[Transfer](resp)
+	if err != nil {
+		return nil, fmt.Errorf("transfer in %s: %w", input.Name, err)
+	}
+
+	return &result.Data, nil
+}
+
+// ShowTransfer returns the progress of an inbound transfer.
+func (c *Client) ShowTransfer(ctx context.Context, name string) (*Transfer, error) {
+	path := fmt.Sprintf("/2/domains/transfers/%s", name)
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("show transfer of %s: %w", name, err)
+	}
+
+	result, err := decodeResponse[Transfer](resp)
+	if err != nil {
+		return nil, fmt.Errorf("show transfer of %s: %w", name, err)
+	}
+
+	return &result.Data, nil
+}
+
+// GetAuthCode returns the EPP authorization code needed to transfer a
+// domain to another registrar.
+func (c *Client) GetAuthCode(ctx context.Context, domain string) (string, error) {
+	path := fmt.Sprintf("/2/domains/domains/%s/auth_code", domain)
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return "", fmt.Errorf("get auth code for %s: %w", domain, err)
+	}
+
+	result, err := decodeResponse[AuthCode](resp)
+	if err != nil {
+		return "", fmt.Errorf("get auth code for %s: %w", domain, err)
+	}
+
+	return result.Data.AuthCode, nil
+}
+
+// SetTransferLock sets or clears the registrar transfer lock of a domain.
+func (c *Client) SetTransferLock(ctx context.Context, domain string, locked bool) error {
+	path := fmt.Sprintf("/2/domains/domains/%s/transfer_lock", domain)
+
+	body, err := json.Marshal(TransferLockInput{Locked: locked})
+	if err != nil {
+		return fmt.Errorf("marshal transfer lock for %s: %w", domain, err)
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("set transfer lock for %s: %w", domain, err)
+	}
+
+	if err := decodeEmptyResponse(resp); err != nil {
+		return fmt.Errorf("set transfer lock for %s: %w", domain, err)
+	}
+
+	return nil
+}
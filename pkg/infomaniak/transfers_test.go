@@ -0,0 +1,119 @@
+package infomaniak
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDomainTransferState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		status      []string
+		wantLocked  bool
+		wantPending bool
+	}{
+		{name: "no status", status: nil},
+		{name: "locked", status: []string{"ok", StatusClientTransferProhibited}, wantLocked: true},
+		{name: "pending", status: []string{StatusPendingTransfer}, wantPending: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := Domain{Status: tt.status}
+			if got := d.TransferLocked(); got != tt.wantLocked {
+				t.Errorf("TransferLocked() = %v, want %v", got, tt.wantLocked)
+			}
+			if got := d.PendingTransfer(); got != tt.wantPending {
+				t.Errorf("PendingTransfer() = %v, want %v", got, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestTransferIn(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/2/domains/transfers":
+			var body TransferInInput
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode request body: %v", err)
+			}
+			if body.Name != "example.com" || body.AuthCode != "s3cr3t" {
+				t.Errorf("body = %+v", body)
+			}
+			_ = json.NewEncoder(w).Encode(Response[Transfer]{Result: "success", Data: Transfer{Name: "example.com", Status: "pending"}})
+		case r.Method == http.MethodGet && r.URL.Path == "/2/domains/transfers/example.com":
+			_ = json.NewEncoder(w).Encode(Response[Transfer]{Result: "success", Data: Transfer{Name: "example.com", Status: TransferCompleted}})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("tok", WithBaseURL(srv.URL))
+
+	tr, err := c.TransferIn(context.Background(), TransferInInput{Name: "example.com", AuthCode: "s3cr3t"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tr.Done() {
+		t.Errorf("new transfer reported as done: %+v", tr)
+	}
+
+	tr, err = c.ShowTransfer(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tr.Done() {
+		t.Errorf("completed transfer not reported as done: %+v", tr)
+	}
+}
+
+func TestAuthCodeAndLock(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2/domains/domains/example.ch/auth_code":
+			_ = json.NewEncoder(w).Encode(Response[AuthCode]{Result: "success", Data: AuthCode{AuthCode: "abc-123"}})
+		case "/2/domains/domains/example.ch/transfer_lock":
+			if r.Method != http.MethodPut {
+				t.Errorf("method = %q, want PUT", r.Method)
+			}
+			var body TransferLockInput
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode request body: %v", err)
+			}
+			if body.Locked {
+				t.Error("locked = true, want false")
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New("tok", WithBaseURL(srv.URL))
+
+	code, err := c.GetAuthCode(context.Background(), "example.ch")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code != "abc-123" {
+		t.Errorf("auth code = %q, want %q", code, "abc-123")
+	}
+
+	if err := c.SetTransferLock(context.Background(), "example.ch", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
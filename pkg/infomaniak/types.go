@@ -0,0 +1,222 @@
+package infomaniak
+
+// Response wraps every Infomaniak API response. The pagination fields are
+// only set by list endpoints.
+type Response[T any] struct {
+	Result  string     `json:"result"`
+	Data    T          `json:"data,omitempty"`
+	Error   *ErrorBody `json:"error,omitempty"`
+	Page    int        `json:"page,omitempty"`
+	Pages   int        `json:"pages,omitempty"`
+	Total   int        `json:"total,omitempty"`
+	PerPage int        `json:"items_per_page,omitempty"`
+}
+
+// ErrorBody contains error details from the API.
+type ErrorBody struct {
+	Code        string        `json:"code"`
+	Description string        `json:"description"`
+	Errors      []ErrorDetail `json:"errors,omitempty"`
+}
+
+// ErrorDetail provides granular validation error context.
+type ErrorDetail struct {
+	Code        string            `json:"code"`
+	Description string            `json:"description"`
+	Context     map[string]string `json:"context,omitempty"`
+}
+
+// Domain represents a domain returned by the Infomaniak API.
+type Domain struct {
+	Name        string         `json:"name"`
+	TLD         string         `json:"tld"`
+	Status      []string       `json:"status"`
+	IsPremium   bool           `json:"is_premium"`
+	CreatedAt   int64          `json:"created_at"`
+	ExpiresAt   int64          `json:"expires_at"`
+	Options     DomainOptions  `json:"options"`
+	Contacts    DomainContacts `json:"contacts"`
+	Nameservers []string       `json:"nameservers,omitempty"`
+	AutoRenew   bool           `json:"auto_renew"`
+}
+
+// DomainOptions holds optional flags for a domain.
+type DomainOptions struct {
+	DNSAnycast      bool `json:"dns_anycast"`
+	RenewalWarranty bool `json:"renewal_warranty"`
+	DomainPrivacy   bool `json:"domain_privacy"`
+	DNSSEC          bool `json:"dnssec"`
+}
+
+// DomainContacts contains all contact types for a domain.
+type DomainContacts struct {
+	Owner   *Contact `json:"owner,omitempty"`
+	Admin   *Contact `json:"admin,omitempty"`
+	Tech    *Contact `json:"tech,omitempty"`
+	Billing *Contact `json:"billing,omitempty"`
+}
+
+// Contact represents a domain contact record.
+type Contact struct {
+	ID           int    `json:"id"`
+	Type         string `json:"type"`
+	FirstName    string `json:"first_name,omitempty"`
+	LastName     string `json:"last_name,omitempty"`
+	Organization string `json:"organization,omitempty"`
+	Street       string `json:"street,omitempty"`
+	City         string `json:"city,omitempty"`
+	Zip          string `json:"zip,omitempty"`
+	Country      string `json:"country,omitempty"`
+	Phone        string `json:"phone"`
+	Fax          string `json:"fax"`
+	Email        string `json:"email"`
+	IsValidated  bool   `json:"is_validated"`
+	ValidatedAt  *int64 `json:"validated_at"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
+// ContactInput is the request body for creating or updating a contact. Nil
+// fields are left out of the request; an empty string clears the field.
+type ContactInput struct {
+	Type         *string `json:"type,omitempty"`
+	FirstName    *string `json:"first_name,omitempty"`
+	LastName     *string `json:"last_name,omitempty"`
+	Organization *string `json:"organization,omitempty"`
+	Street       *string `json:"street,omitempty"`
+	City         *string `json:"city,omitempty"`
+	Zip          *string `json:"zip,omitempty"`
+	Country      *string `json:"country,omitempty"`
+	Phone        *string `json:"phone,omitempty"`
+	Fax          *string `json:"fax,omitempty"`
+	Email        *string `json:"email,omitempty"`
+}
+
+// SetContactsInput is the request body for reassigning the contacts of a
+// domain. Zero IDs leave the corresponding role unchanged.
+type SetContactsInput struct {
+	Owner   int `json:"owner,omitempty"`
+	Admin   int `json:"admin,omitempty"`
+	Tech    int `json:"tech,omitempty"`
+	Billing int `json:"billing,omitempty"`
+}
+
+// Price is an amount charged for a domain operation.
+type Price struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// Availability reports whether a domain name can be registered. Price is
+// the yearly registration price and is only set for available names.
+type Availability struct {
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+	IsPremium bool   `json:"is_premium"`
+	Price     *Price `json:"price,omitempty"`
+}
+
+// RegisterDomainInput is the request body for registering a domain.
+type RegisterDomainInput struct {
+	Name        string           `json:"name"`
+	Years       int              `json:"years"`
+	Contacts    SetContactsInput `json:"contacts"`
+	Nameservers []string         `json:"nameservers,omitempty"`
+}
+
+// RenewDomainInput is the request body for renewing a domain.
+type RenewDomainInput struct {
+	Years int `json:"years"`
+}
+
+// AutoRenewInput is the request body for toggling automatic renewal.
+type AutoRenewInput struct {
+	AutoRenew bool `json:"auto_renew"`
+}
+
+// Transfer describes an inbound domain transfer.
+type Transfer struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at,omitempty"`
+}
+
+// TransferInInput is the request body for transferring a domain in from
+// another registrar.
+type TransferInInput struct {
+	Name     string           `json:"name"`
+	AuthCode string           `json:"auth_code"`
+	Contacts SetContactsInput `json:"contacts"`
+}
+
+// AuthCode holds the EPP authorization code of a domain.
+type AuthCode struct {
+	AuthCode string `json:"auth_code"`
+}
+
+// TransferLockInput is the request body for setting the transfer lock.
+type TransferLockInput struct {
+	Locked bool `json:"locked"`
+}
+
+// OptionsInput is the request body for changing domain options. Nil fields
+// are left unchanged.
+type OptionsInput struct {
+	DNSAnycast      *bool `json:"dns_anycast,omitempty"`
+	RenewalWarranty *bool `json:"renewal_warranty,omitempty"`
+	DomainPrivacy   *bool `json:"domain_privacy,omitempty"`
+}
+
+// UpdateNameserversInput is the request body for updating nameservers.
+type UpdateNameserversInput struct {
+	Nameservers          []string `json:"nameservers"`
+	VerifyNSAvailability bool     `json:"verify_ns_availability"`
+}
+
+// Record represents a DNS record in a zone managed by Infomaniak.
+type Record struct {
+	ID        int    `json:"id"`
+	Source    string `json:"source"`
+	Type      string `json:"type"`
+	TTL       int    `json:"ttl"`
+	Target    string `json:"target"`
+	Priority  int    `json:"priority,omitempty"`
+	Weight    int    `json:"weight,omitempty"`
+	Port      int    `json:"port,omitempty"`
+	UpdatedAt int64  `json:"updated_at,omitempty"`
+}
+
+// RecordInput is the request body for creating or updating a DNS record.
+type RecordInput struct {
+	Source   string `json:"source"`
+	Type     string `json:"type"`
+	TTL      int    `json:"ttl,omitempty"`
+	Target   string `json:"target"`
+	Priority int    `json:"priority,omitempty"`
+	Weight   int    `json:"weight,omitempty"`
+	Port     int    `json:"port,omitempty"`
+}
+
+// DNSSEC describes the DNSSEC state of a domain at the registry.
+type DNSSEC struct {
+	Enabled   bool       `json:"enabled"`
+	DSRecords []DSRecord `json:"ds_records"`
+}
+
+// DSRecord is a delegation signer record published in the parent zone.
+type DSRecord struct {
+	ID         int    `json:"id"`
+	KeyTag     int    `json:"key_tag"`
+	Algorithm  int    `json:"algorithm"`
+	DigestType int    `json:"digest_type"`
+	Digest     string `json:"digest"`
+}
+
+// DSRecordInput is the request body for adding a DS record.
+type DSRecordInput struct {
+	KeyTag     int    `json:"key_tag"`
+	Algorithm  int    `json:"algorithm"`
+	DigestType int    `json:"digest_type"`
+	Digest     string `json:"digest"`
+}